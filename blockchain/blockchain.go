@@ -25,6 +25,7 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/helpers/multicast"
+	"pandora-pay/helpers/recovery"
 	"pandora-pay/mempool"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/store"
@@ -33,7 +34,6 @@ import (
 	"pandora-pay/wallet"
 	"strconv"
 	"sync"
-	"time"
 )
 
 type Blockchain struct {
@@ -61,11 +61,11 @@ func (chain *Blockchain) validateBlocks(blocksComplete []*block_complete.BlockCo
 	for _, blkComplete := range blocksComplete {
 
 		if err = blkComplete.Verify(); err != nil {
-			return
+			return invalidBlock(err)
 		}
 
 		if err = txs_validator.TxsValidator.ValidateTxs(blkComplete.Txs); err != nil {
-			return
+			return invalidBlock(err)
 		}
 
 	}
@@ -218,9 +218,8 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 
 				for _, blkComplete := range blocksComplete {
 
-					//check block height
-					if blkComplete.Block.Height != newChainData.Height {
-						return errors.New("Block Height is not right!")
+					if err = validateBlockHeader(blkComplete.Block, newChainData); err != nil {
+						return
 					}
 
 					//check existance of a tx with payloads
@@ -230,17 +229,17 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 							txBase := tx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
 							if len(txBase.Payloads) == 2 && txBase.Payloads[0].PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING && txBase.Payloads[1].PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD {
 								if foundStakingRewardTx != nil {
-									return errors.New("Multiple txs with staking & reward payloads")
+									return invalidBlock(errors.New("Multiple txs with staking & reward payloads"))
 								}
 								foundStakingRewardTx = tx
 								if index != len(blkComplete.Txs)-1 {
-									return errors.New("Staking reward tx should be the last one")
+									return invalidBlock(errors.New("Staking reward tx should be the last one"))
 								}
 								continue
 							}
 							for _, payload := range txBase.Payloads {
 								if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD {
-									return errors.New("Block contains other staking/reward payloads")
+									return invalidBlock(errors.New("Block contains other staking/reward payloads"))
 								}
 							}
 						}
@@ -248,32 +247,32 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 
 					// not staking and reward tx
 					if foundStakingRewardTx == nil {
-						return errors.New("Block is missing Staking and Reward Transaction")
+						return invalidBlock(errors.New("Block is missing Staking and Reward Transaction"))
 					}
 
 					//check blkComplete balance
 					foundStakingRewardTxBase := foundStakingRewardTx.TransactionBaseInterface.(*transaction_zether.TransactionZether)
 					if foundStakingRewardTxBase.Payloads[0].BurnValue < config_stake.GetRequiredStake(blkComplete.Block.Height) {
-						return errors.New("Staked amount is not enough!")
+						return invalidBlock(errors.New("Staked amount is not enough!"))
 					}
 
 					//verify staking amount
 					if foundStakingRewardTxBase.Payloads[0].BurnValue != blkComplete.StakingAmount {
-						return errors.New("Staked amount is different that the burn value")
+						return invalidBlock(errors.New("Staked amount is different that the burn value"))
 					}
 
 					if !bytes.Equal(foundStakingRewardTxBase.Payloads[0].Proof.Nonce(), blkComplete.StakingNonce) {
-						return errors.New("Staked Proof Nonce is not matching with the one specified in the block")
+						return invalidBlock(errors.New("Staked Proof Nonce is not matching with the one specified in the block"))
 					}
 
 					//verify forger reward
 					var reward, finalForgerReward uint64
 					if reward, finalForgerReward, err = blockchain_types.ComputeBlockReward(blkComplete.Height, blkComplete.Txs); err != nil {
-						return
+						return invalidBlock(err)
 					}
 
 					if foundStakingRewardTxBase.Payloads[1].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraStakingReward).Reward > finalForgerReward {
-						return fmt.Errorf("Payload Reward %d is bigger than it should be %d", foundStakingRewardTxBase.Payloads[1].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraStakingReward).Reward, finalForgerReward)
+						return invalidBlock(fmt.Errorf("Payload Reward %d is bigger than it should be %d", foundStakingRewardTxBase.Payloads[1].Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraStakingReward).Reward, finalForgerReward))
 					}
 
 					//increase supply
@@ -291,28 +290,8 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 
 					newChainData.Supply = ast.Supply
 
-					if difficulty.CheckKernelHashBig(blkComplete.Block.Bloom.KernelHashStaked, newChainData.Target) != true {
-						return errors.New("KernelHash Difficulty is not met")
-					}
-
-					if !bytes.Equal(blkComplete.Block.PrevHash, newChainData.Hash) {
-						return errors.New("PrevHash doesn't match Genesis prevHash")
-					}
-
-					if !bytes.Equal(blkComplete.Block.PrevKernelHash, newChainData.KernelHash) {
-						return errors.New("PrevHash doesn't match Genesis prevKernelHash")
-					}
-
-					if blkComplete.Block.Timestamp < newChainData.Timestamp {
-						return errors.New("Timestamp has to be greater than the last timestmap")
-					}
-
-					if blkComplete.Block.Timestamp > uint64(time.Now().UTC().Unix())+config.NETWORK_TIMESTAMP_DRIFT_MAX {
-						return errors.New("Timestamp is too much into the future")
-					}
-
 					if err = blkComplete.IncludeBlockComplete(dataStorage); err != nil {
-						return fmt.Errorf("Error including block %d into Blockchain: %s", blkComplete.Height, err.Error())
+						return invalidBlock(fmt.Errorf("Error including block %d into Blockchain: %s", blkComplete.Height, err.Error()))
 					}
 
 					if err = dataStorage.ProcessPendingStakes(blkComplete.Height); err != nil {
@@ -326,8 +305,8 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 					//to detect if the savedBlock was done correctly
 					savedBlock = false
 
-					if allTransactionsChanges, err = chain.saveBlockComplete(writer, blkComplete, newChainData.TransactionsCount, removedTxHashes, allTransactionsChanges, dataStorage); err != nil {
-						return errors.New("Error saving block complete: " + err.Error())
+					if allTransactionsChanges, err = chain.saveBlockComplete(writer, blkComplete, newChainData.TransactionsCount, removedTxHashes, allTransactionsChanges, dataStorage, calledByForging); err != nil {
+						return fmt.Errorf("Error saving block complete: %w", err)
 					}
 
 					if len(removedBlocksHeights) > 0 {
@@ -403,7 +382,9 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 
 					//removing unused transactions
 					if config.NODE_PROVIDE_EXTENDED_INFO_APP {
-						removeUnusedTransactions(writer, newChainData.TransactionsCount, removedBlocksTransactionsCount)
+						if err = removeUnusedTransactions(writer, newChainData.TransactionsCount, removedBlocksTransactionsCount); err != nil {
+							return
+						}
 					}
 				}
 
@@ -468,6 +449,12 @@ func (chain *Blockchain) AddBlocks(blocksComplete []*block_complete.BlockComplet
 		kernelHash = newChainData.KernelHash
 		chain.ChainData.Store(newChainData)
 		chain.mempool.ContinueProcessingCn <- mempool.CONTINUE_PROCESSING_NO_ERROR
+
+		if config.PRUNE_BLOCKS > 0 {
+			if _, err := chain.pruneBlocks(); err != nil {
+				gui.GUI.Error("Error pruning the blocks", err)
+			}
+		}
 	} else {
 		chain.mempool.ContinueProcessingCn <- mempool.CONTINUE_PROCESSING_ERROR
 	}
@@ -524,6 +511,10 @@ func CreateBlockchain(mempool *mempool.Mempool) (*Blockchain, error) {
 
 func (chain *Blockchain) InitializeChain() (err error) {
 
+	if err = chain.migrateStore(); err != nil {
+		return
+	}
+
 	if err = chain.loadBlockchain(); err != nil {
 		if err.Error() != "Chain not found" {
 			return
@@ -539,6 +530,10 @@ func (chain *Blockchain) InitializeChain() (err error) {
 	chainData := chain.GetChainData()
 	chainData.updateChainInfo()
 
+	if config.PRUNE_BLOCKS > 0 {
+		recovery.SafeGo(chain.pruneCatchUp)
+	}
+
 	return
 }
 
@@ -0,0 +1,25 @@
+package blockchain
+
+import "errors"
+
+//the block is invalid no matter the state of the node, so the peers that sent it can be penalized
+type InvalidBlockError struct {
+	err error
+}
+
+func (this *InvalidBlockError) Error() string {
+	return this.err.Error()
+}
+
+func (this *InvalidBlockError) Unwrap() error {
+	return this.err
+}
+
+func invalidBlock(err error) error {
+	return &InvalidBlockError{err}
+}
+
+func IsInvalidBlock(err error) bool {
+	var invalid *InvalidBlockError
+	return errors.As(err, &invalid)
+}
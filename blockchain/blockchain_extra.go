@@ -11,6 +11,7 @@ import (
 	"pandora-pay/blockchain/data_storage/accounts"
 	"pandora-pay/blockchain/data_storage/accounts/account"
 	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/identities/identity"
 	"pandora-pay/blockchain/data_storage/registrations"
 	"pandora-pay/blockchain/forging/forging_block_work"
 	"pandora-pay/blockchain/genesis"
@@ -98,6 +99,21 @@ func (chain *Blockchain) initializeNewChain(chainData *BlockchainData, dataStora
 
 	}
 
+	for _, issuer := range genesis.GenesisData.Issuers {
+
+		var addr *addresses.Address
+		if addr, err = addresses.DecodeAddr(issuer); err != nil {
+			return
+		}
+
+		id := identity.NewIdentity(addr.PublicKey, 0)
+		id.Issuer = true
+
+		if err = dataStorage.Identities.Create(string(addr.PublicKey), id); err != nil {
+			return
+		}
+	}
+
 	ast := &asset.Asset{
 		nil,
 		0,
@@ -199,7 +215,7 @@ func (chain *Blockchain) createNextBlockForForging(chainData *BlockchainData, ne
 		} else {
 			blk = &block.Block{
 				BlockHeader: &block.BlockHeader{
-					Version: 0,
+					Version: block.GetBlockVersion(chainData.Height),
 					Height:  chainData.Height,
 				},
 				MerkleHash:     cryptography.SHA3([]byte{}),
@@ -211,6 +227,11 @@ func (chain *Blockchain) createNextBlockForForging(chainData *BlockchainData, ne
 
 		blk.StakingNonce = make([]byte, 32)
 
+		//the state root is computed when the block is forged
+		if blk.Version == block.BLOCK_VERSION_STATE_ROOT {
+			blk.StateRoot = make([]byte, cryptography.HashSize)
+		}
+
 		blk.BloomSerializedNow(blk.SerializeManualToBytes())
 
 		blkComplete := &block_complete.BlockComplete{
@@ -6,6 +6,7 @@ import (
 	"pandora-pay/blockchain/blocks/block_complete"
 	"pandora-pay/blockchain/data_storage/assets"
 	"pandora-pay/blockchain/info"
+	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/store/store_db/store_db_interface"
@@ -45,7 +46,7 @@ func removeBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterfa
 			}
 
 			count -= 1
-			writer.Delete("addrTx:" + string(key) + ":" + strconv.FormatUint(count, 10))
+			writer.Delete("addrTx:" + string(key) + ":" + helpers.FormatIndexKey(count))
 			if count == 0 {
 				writer.Delete("addrTxsCount:" + string(key))
 			} else {
@@ -59,11 +60,8 @@ func removeBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterfa
 	return
 }
 
-func removeUnusedTransactions(writer store_db_interface.StoreDBTransactionInterface, starting, count uint64) {
-
-	for i := starting; i < count; i++ {
-		writer.Delete("txHash_ByHeight" + strconv.FormatUint(i, 10))
-	}
+func removeUnusedTransactions(writer store_db_interface.StoreDBTransactionInterface, starting, count uint64) error {
+	return writer.DeleteRange("txHash_ByHeight", helpers.FormatIndexKey(starting), helpers.FormatIndexKey(count))
 }
 
 func removeTxsInfo(writer store_db_interface.StoreDBTransactionInterface, removedTxHashes map[string][]byte) {
@@ -128,8 +126,7 @@ func saveBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterface
 	for i, tx := range blkComplete.Txs {
 
 		height := transactionsCount + uint64(i)
-		indexStr := strconv.FormatUint(height, 10)
-		writer.Put("txHash_ByHeight"+indexStr, tx.Bloom.Hash)
+		writer.Put("txHash_ByHeight"+helpers.FormatIndexKey(height), tx.Bloom.Hash)
 
 		var buffer []byte
 		if buffer, err = msgpack.Marshal(&info.TxInfo{
@@ -182,7 +179,7 @@ func saveBlockCompleteInfo(writer store_db_interface.StoreDBTransactionInterface
 				key, count,
 			}
 
-			writer.Put("addrTx:"+keyStr+":"+strconv.FormatUint(count, 10), tx.Bloom.Hash)
+			writer.Put("addrTx:"+keyStr+":"+helpers.FormatIndexKey(count), tx.Bloom.Hash)
 			writer.Put("addrTxsCount:"+keyStr, []byte(strconv.FormatUint(count+1, 10)))
 		}
 
@@ -0,0 +1,201 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block/difficulty"
+	"pandora-pay/config"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+	"time"
+)
+
+// AddHeaders is used by the header only app nodes. The headers are validated with the same header checks as the full blocks.
+// The stake and the state root can't be verified against the state, so they are only checked to be well formed.
+// A peer could forge a heavier chain with a stake it doesn't own, so the header only nodes require a permissioned network and download the headers only from the allowed nodes
+func (chain *Blockchain) AddHeaders(blks []*block.Block) (err error) {
+
+	if len(blks) == 0 {
+		return errors.New("Headers length is ZERO")
+	}
+
+	for i, blk := range blks {
+		if err = blk.BloomNow(); err != nil {
+			return invalidBlock(err)
+		}
+		if i > 0 && blk.Height != blks[i-1].Height+1 {
+			return invalidBlock(errors.New("Headers are not consecutive"))
+		}
+	}
+
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	chainData := chain.GetChainData()
+
+	var newChainData *BlockchainData
+
+	if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		//let's filter existing headers
+		for i := len(blks) - 1; i >= 0; i-- {
+			if blks[i].Height < chainData.Height {
+				var hash []byte
+				if hash, err = chain.LoadBlockHash(writer, blks[i].Height); err != nil {
+					return
+				}
+				if bytes.Equal(hash, blks[i].Bloom.Hash) {
+					blks = blks[i+1:]
+					break
+				}
+			}
+		}
+
+		if len(blks) == 0 {
+			return errors.New("headers are identical now")
+		}
+
+		first := blks[0]
+		if first.Height > chainData.Height {
+			return errors.New("First header is not linked")
+		}
+
+		if first.Height < chainData.Height {
+			if chainData.Height-first.Height > config.FORK_MAX_UNCLE_ALLOWED {
+				return errors.New("Headers fork is too deep")
+			}
+
+			for height := first.Height; height < chainData.Height; height++ {
+				chain.removeHeader(writer, height)
+			}
+
+			if first.Height == 0 {
+				newChainData = chain.createGenesisBlockchainData()
+			} else {
+				newChainData = &BlockchainData{}
+				if err = newChainData.loadBlockchainInfo(writer, first.Height); err != nil {
+					return
+				}
+			}
+		} else {
+			newChainData = &BlockchainData{
+				helpers.CloneBytes(chainData.Hash),
+				helpers.CloneBytes(chainData.PrevHash),
+				helpers.CloneBytes(chainData.KernelHash),
+				helpers.CloneBytes(chainData.PrevKernelHash),
+				chainData.Height,
+				chainData.Timestamp,
+				new(big.Int).Set(chainData.Target),
+				new(big.Int).Set(chainData.BigTotalDifficulty),
+				chainData.TransactionsCount,
+				chainData.AccountsCount,
+				chainData.AssetsCount,
+				chainData.Supply,
+				chainData.ConsecutiveSelfForged,
+			}
+		}
+
+		for _, blk := range blks {
+
+			if err = validateBlockHeader(blk, newChainData); err != nil {
+				return
+			}
+
+			blockHeightStr := strconv.FormatUint(blk.Height, 10)
+			writer.Put("block_ByHash"+string(blk.Bloom.Hash), helpers.SerializeToBytes(blk))
+			writer.Put("blockHash_ByHeight"+blockHeightStr, blk.Bloom.Hash)
+			writer.Put("blockKernelHash_ByHeight"+blockHeightStr, blk.Bloom.KernelHash)
+			writer.Put("blockHeight_ByHash"+string(blk.Bloom.Hash), []byte(blockHeightStr))
+
+			newChainData.PrevHash = newChainData.Hash
+			newChainData.Hash = blk.Bloom.Hash
+			newChainData.PrevKernelHash = newChainData.KernelHash
+			newChainData.KernelHash = blk.Bloom.KernelHash
+			newChainData.Timestamp = blk.Timestamp
+
+			newChainData.BigTotalDifficulty = new(big.Int).Add(newChainData.BigTotalDifficulty, difficulty.ConvertTargetToDifficulty(newChainData.Target))
+
+			if newChainData.Target, err = newChainData.computeNextTargetBig(writer); err != nil {
+				return
+			}
+
+			newChainData.Height += 1
+
+			newChainData.saveTotalDifficultyExtra(writer)
+			newChainData.saveBlockchainHeight(writer)
+			if err = newChainData.saveBlockchainInfo(writer); err != nil {
+				return
+			}
+		}
+
+		if chainData.BigTotalDifficulty.Cmp(newChainData.BigTotalDifficulty) >= 0 {
+			return errors.New("Headers chain has a smaller total difficulty")
+		}
+
+		return newChainData.saveBlockchain(writer)
+	}); err != nil {
+		return
+	}
+
+	gui.GUI.Info("Included headers " + strconv.FormatUint(blks[0].Height, 10) + " ... " + strconv.FormatUint(blks[len(blks)-1].Height, 10))
+
+	chain.ChainData.Store(newChainData)
+	newChainData.updateChainInfo()
+
+	return
+}
+
+//the checks that don't require the state. They are used for the full blocks and for the headers
+func validateBlockHeader(blk *block.Block, chainData *BlockchainData) error {
+
+	if err := blk.Validate(); err != nil {
+		return invalidBlock(err)
+	}
+	if err := blk.Verify(); err != nil {
+		return invalidBlock(err)
+	}
+	if blk.Height != chainData.Height {
+		return invalidBlock(errors.New("Block Height is not right!"))
+	}
+	if blk.Version != block.GetBlockVersion(blk.Height) {
+		return invalidBlock(errors.New("Block Version is invalid for the height"))
+	}
+	if blk.StakingAmount < config_stake.GetRequiredStake(blk.Height) {
+		return invalidBlock(errors.New("Staked amount is not enough!"))
+	}
+	if !difficulty.CheckKernelHashBig(blk.Bloom.KernelHashStaked, chainData.Target) {
+		return invalidBlock(errors.New("KernelHash Difficulty is not met"))
+	}
+	if !bytes.Equal(blk.PrevHash, chainData.Hash) {
+		return invalidBlock(errors.New("PrevHash doesn't match Genesis prevHash"))
+	}
+	if !bytes.Equal(blk.PrevKernelHash, chainData.KernelHash) {
+		return invalidBlock(errors.New("PrevHash doesn't match Genesis prevKernelHash"))
+	}
+	if blk.Timestamp < chainData.Timestamp {
+		return invalidBlock(errors.New("Timestamp has to be greater than the last timestmap"))
+	}
+	if blk.Timestamp > uint64(time.Now().UTC().Unix())+config.NETWORK_TIMESTAMP_DRIFT_MAX {
+		return errors.New("Timestamp is too much into the future")
+	}
+
+	return nil
+}
+
+func (chain *Blockchain) removeHeader(writer store_db_interface.StoreDBTransactionInterface, blockHeight uint64) {
+
+	blockHeightStr := strconv.FormatUint(blockHeight, 10)
+
+	if hash := writer.Get("blockHash_ByHeight" + blockHeightStr); hash != nil {
+		writer.Delete("block_ByHash" + string(hash))
+		writer.Delete("blockHeight_ByHash" + string(hash))
+	}
+	writer.Delete("blockHash_ByHeight" + blockHeightStr)
+	writer.Delete("blockKernelHash_ByHeight" + blockHeightStr)
+}
@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/config"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"testing"
+	"time"
+)
+
+func newTestHeader(chainData *BlockchainData, change func(blk *block.Block)) (*block.Block, error) {
+
+	blk := &block.Block{
+		BlockHeader:    &block.BlockHeader{Version: block.GetBlockVersion(chainData.Height), Height: chainData.Height},
+		MerkleHash:     cryptography.RandomHash(),
+		PrevHash:       chainData.Hash,
+		PrevKernelHash: chainData.KernelHash,
+		Timestamp:      chainData.Timestamp + config.BLOCK_TIME,
+		StakingAmount:  config_stake.GetRequiredStake(chainData.Height),
+		StakingNonce:   make([]byte, 32),
+	}
+	if blk.Version == block.BLOCK_VERSION_STATE_ROOT {
+		blk.StateRoot = cryptography.RandomHash()
+	}
+	if change != nil {
+		change(blk)
+	}
+
+	return blk, blk.BloomNow()
+}
+
+func TestValidateBlockHeader(t *testing.T) {
+
+	chainData := &BlockchainData{
+		Hash:       cryptography.RandomHash(),
+		KernelHash: cryptography.RandomHash(),
+		Height:     10,
+		Timestamp:  uint64(time.Now().Unix()) - 1000,
+		Target:     new(big.Int).Set(config.BIG_INT_MAX_256),
+	}
+
+	blk, err := newTestHeader(chainData, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, validateBlockHeader(blk, chainData))
+
+	for _, change := range []func(blk *block.Block){
+		func(blk *block.Block) { blk.Height += 1 },
+		func(blk *block.Block) { blk.StakingAmount -= 1 },
+		func(blk *block.Block) { blk.PrevHash = cryptography.RandomHash() },
+		func(blk *block.Block) { blk.PrevKernelHash = cryptography.RandomHash() },
+		func(blk *block.Block) { blk.Timestamp = chainData.Timestamp - 1 },
+	} {
+		blk, err = newTestHeader(chainData, change)
+		assert.Nil(t, err)
+		assert.True(t, IsInvalidBlock(validateBlockHeader(blk, chainData)))
+	}
+
+	//the clock of the node can drift, so the peer is not penalized
+	blk, err = newTestHeader(chainData, func(blk *block.Block) { blk.Timestamp = uint64(time.Now().Unix()) + 1000 })
+	assert.Nil(t, err)
+	err = validateBlockHeader(blk, chainData)
+	assert.NotNil(t, err)
+	assert.False(t, IsInvalidBlock(err))
+	assert.False(t, IsInvalidBlock(fmt.Errorf("Error saving block complete: %w", err)))
+	assert.True(t, IsInvalidBlock(fmt.Errorf("Error saving block complete: %w", invalidBlock(err))))
+
+	//the version and the state root must match the height
+	blk, err = newTestHeader(chainData, func(blk *block.Block) {
+		blk.Version = block.BLOCK_VERSION_STATE_ROOT
+		blk.StateRoot = cryptography.RandomHash()
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, validateBlockHeader(blk, chainData))
+
+	_, err = newTestHeader(chainData, func(blk *block.Block) {
+		blk.Version = block.BLOCK_VERSION_STATE_ROOT
+	})
+	assert.NotNil(t, err)
+
+	//the kernel hash must meet the target
+	blk, err = newTestHeader(chainData, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, validateBlockHeader(blk, &BlockchainData{
+		Hash:       chainData.Hash,
+		KernelHash: chainData.KernelHash,
+		Height:     chainData.Height,
+		Timestamp:  chainData.Timestamp,
+		Target:     big.NewInt(1),
+	}))
+
+	//the headers that were not bloomed are rejected
+	blk.Bloom = &block.BlockBloom{}
+	assert.NotNil(t, validateBlockHeader(blk, chainData))
+
+}
@@ -0,0 +1,223 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+	"strings"
+)
+
+//version 1 stores the tx indexes with zero padded keys. Version 2 rebuilds the state tree from all the stored hashmaps
+const storeVersion = 2
+
+//it keeps every update of the migration small
+const migrationMaxKeysPerUpdate = 10000
+
+func loadStoreVersion(reader store_db_interface.StoreDBTransactionInterface) uint64 {
+	if data := reader.Get("storeVersion"); data != nil {
+		version, _ := binary.Uvarint(data)
+		return version
+	}
+	return 0
+}
+
+func saveStoreVersion(writer store_db_interface.StoreDBTransactionInterface, version uint64) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, version)
+	writer.Put("storeVersion", buf[:n])
+}
+
+//the index is the text after the last ":" or after the prefix. The padded indexes are already migrated
+func getOldIndexKey(prefix, key string) (string, string, bool) {
+
+	index := strings.TrimPrefix(key, prefix)
+	if position := strings.LastIndexByte(index, ':'); position >= 0 {
+		index = index[position+1:]
+	}
+	if len(index) == len(helpers.FormatIndexKey(0)) {
+		return "", "", false
+	}
+
+	return key[:len(key)-len(index)], index, true
+}
+
+//it moves the keys with decimal indexes to the zero padded ones. It returns true when there are no more keys to move
+func migrateIndexKeys(writer store_db_interface.StoreDBTransactionInterface, prefix string, start *string) (bool, error) {
+
+	keys, values := []string{}, [][]byte{}
+	if err := writer.Iterate(prefix, *start, false, func(key string, value []byte) (bool, error) {
+		if len(keys) == migrationMaxKeysPerUpdate {
+			return false, nil
+		}
+		if _, _, old := getOldIndexKey(prefix, key); old {
+			keys = append(keys, key)
+			values = append(values, helpers.CloneBytes(value))
+		}
+		*start = key[len(prefix):]
+		return true, nil
+	}); err != nil {
+		return false, err
+	}
+
+	for i, key := range keys {
+		base, indexStr, _ := getOldIndexKey(prefix, key)
+
+		index, err := strconv.ParseUint(indexStr, 10, 64)
+		if err != nil {
+			return false, err
+		}
+
+		writer.Delete(key)
+		writer.Put(base+helpers.FormatIndexKey(index), values[i])
+	}
+
+	return len(keys) < migrationMaxKeysPerUpdate, nil
+}
+
+//the names of all the hashmaps written in the state tree. The collections are found by the assets and by the stored conditional payments
+func getStateTreeHashMapNames(reader store_db_interface.StoreDBTransactionInterface) ([]string, error) {
+
+	names := []string{}
+	for _, hashMap := range data_storage.NewDataStorage(reader).GetListWithoutCollections() {
+		names = append(names, hashMap.GetName())
+	}
+
+	if err := reader.Iterate("assets:map:", "", false, func(key string, value []byte) (bool, error) {
+		assetId := key[len("assets:map:"):]
+		names = append(names, "accounts_"+assetId, assetId, assetId+"_dict")
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	prefix, start := "conditionalPayments_", ""
+	for {
+		name := ""
+		if err := reader.Iterate(prefix, start, false, func(key string, value []byte) (bool, error) {
+			name = key[:len(prefix)+strings.IndexByte(key[len(prefix):], ':')]
+			return false, nil
+		}); err != nil {
+			return nil, err
+		}
+		if name == "" {
+			return names, nil
+		}
+		names = append(names, name)
+		start = name[len(prefix):] + ";"
+	}
+}
+
+//the state tree is deleted and written again from the committed elements of every hashmap
+func rebuildStateTree(db store_db_interface.StoreDBInterface) (err error) {
+
+	for done := false; !done; {
+		if err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			keys := []string{}
+			if err = writer.Iterate("stateTree:", "", false, func(key string, value []byte) (bool, error) {
+				if len(keys) == migrationMaxKeysPerUpdate {
+					return false, nil
+				}
+				keys = append(keys, key)
+				return true, nil
+			}); err != nil {
+				return
+			}
+			for _, key := range keys {
+				writer.Delete(key)
+			}
+			done = len(keys) < migrationMaxKeysPerUpdate
+			return
+		}); err != nil {
+			return
+		}
+	}
+
+	var names []string
+	if err = db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		names, err = getStateTreeHashMapNames(reader)
+		return
+	}); err != nil {
+		return
+	}
+
+	for _, name := range names {
+		start, done := "", false
+		for !done {
+			if err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+				done, err = hash_map.WriteStoredStateTree(writer, state_tree.NewStateTree(writer), name, &start, migrationMaxKeysPerUpdate)
+				return
+			}); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+//old stores are migrated only once. The version is saved after all the keys were moved
+func (chain *Blockchain) migrateStore() (err error) {
+
+	var version uint64
+	var exists bool
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		version = loadStoreVersion(reader)
+		exists = reader.Exists("blockchainInfo")
+		return
+	}); err != nil {
+		return
+	}
+
+	if version >= storeVersion {
+		return
+	}
+
+	if exists && version < 1 {
+
+		gui.GUI.Log("Migrating the store to version 1...")
+
+		for _, prefix := range []string{"txHash_ByHeight", "addrTx:"} {
+			start, done := "", false
+			for !done {
+				if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+					done, err = migrateIndexKeys(writer, prefix, &start)
+					return
+				}); err != nil {
+					return
+				}
+			}
+		}
+
+		//the pruned entries are found by iterating the keys now
+		if err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+			return writer.DeleteRange("addrTxsPruned:", "", "")
+		}); err != nil {
+			return
+		}
+
+		gui.GUI.Log("Migrating the store to version 1 done")
+	}
+
+	if exists && version < 2 {
+
+		gui.GUI.Log("Migrating the store to version 2...")
+
+		//the state tree was written only from the changes, so the elements stored before it are missing
+		if err = rebuildStateTree(store.StoreBlockchain.DB); err != nil {
+			return
+		}
+
+		gui.GUI.Log("Migrating the store to version 2 done")
+	}
+
+	return store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		saveStoreVersion(writer, storeVersion)
+		return
+	})
+}
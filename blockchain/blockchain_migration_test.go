@@ -0,0 +1,138 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/assets/asset"
+	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account/asset_fee_liquidity"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"testing"
+)
+
+func TestMigrateIndexKeys(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		for i := uint64(0); i < 25; i++ {
+			writer.Put("txHash_ByHeight"+strconv.FormatUint(i, 10), []byte{byte(i)})
+			writer.Put("addrTx:key:1:"+strconv.FormatUint(i, 10), []byte{byte(i)})
+		}
+		writer.Put("addrTx:key:1:"+helpers.FormatIndexKey(25), []byte{25})
+		writer.Put("addrTxsCount:key:1", []byte("26"))
+		return
+	}))
+
+	for _, prefix := range []string{"txHash_ByHeight", "addrTx:"} {
+		start, done, updates := "", false, 0
+		for !done {
+			assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+				done, err = migrateIndexKeys(writer, prefix, &start)
+				return
+			}))
+			updates++
+		}
+		assert.Equal(t, 1, updates)
+	}
+
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		for i := uint64(0); i < 25; i++ {
+			assert.Equal(t, []byte{byte(i)}, reader.Get("txHash_ByHeight"+helpers.FormatIndexKey(i)))
+			assert.Equal(t, []byte{byte(i)}, reader.Get("addrTx:key:1:"+helpers.FormatIndexKey(i)))
+			assert.False(t, reader.Exists("addrTx:key:1:"+strconv.FormatUint(i, 10)))
+		}
+		assert.Equal(t, []byte{25}, reader.Get("addrTx:key:1:"+helpers.FormatIndexKey(25)))
+		assert.Equal(t, []byte("26"), reader.Get("addrTxsCount:key:1"))
+		return
+	}))
+
+}
+
+func TestRebuildStateTree(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+
+	createTestSnapshotChain(t, db, 5)
+
+	//the accounts, the fee liquidity heap and the conditional payments are stored in collections
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		assetId := helpers.RandomBytes(config_coins.ASSET_LENGTH)
+		ast := asset.NewAsset(assetId, 0)
+		ast.Name, ast.Ticker, ast.Description = "TEST", "TST", "test"
+		ast.Identification = "TST-" + hex.EncodeToString(assetId[:3])
+		assert.Nil(t, dataStorage.Asts.CreateAsset(assetId, ast))
+
+		for i := 0; i < 3; i++ {
+			publicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+			_, err = dataStorage.CreateRegistration(publicKey, false, nil)
+			assert.Nil(t, err)
+			_, _, err = dataStorage.CreateAccount(assetId, publicKey, true)
+			assert.Nil(t, err)
+			assert.Nil(t, dataStorage.AstsFeeLiquidityCollection.UpdateLiquidity(publicKey, uint64(i+1), 0, assetId, asset_fee_liquidity.UPDATE_LIQUIDITY_INSERTED))
+		}
+
+		for _, height := range []uint64{12, 120, 1200} {
+			payments, err := dataStorage.ConditionalPaymentsCollection.GetMap(height)
+			assert.Nil(t, err)
+			key := string(cryptography.RandomHash()) + "_0"
+			condPayment := conditional_payment.NewConditionalPayment([]byte(key), 0, height)
+			condPayment.MultisigThreshold = 1
+			condPayment.MultisigPublicKeys = [][]byte{helpers.RandomBytes(cryptography.PublicKeySize)}
+			assert.Nil(t, payments.Update(key, condPayment))
+		}
+
+		return dataStorage.CommitChanges()
+	}))
+
+	var root []byte
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		root = state_tree.NewStateTree(reader).GetRoot()
+		return
+	}))
+
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		return writer.DeleteRange("stateTree:", "", "")
+	}))
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		assert.NotEqual(t, root, state_tree.NewStateTree(reader).GetRoot())
+		return
+	}))
+
+	//the migration runs only once for the stores of version 1
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		saveStoreVersion(writer, 1)
+		return
+	}))
+
+	if gui.GUI == nil {
+		gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+		assert.Nil(t, err)
+	}
+	store.StoreBlockchain = &store.Store{"blockchain", true, db}
+
+	chain := &Blockchain{}
+	assert.Nil(t, chain.migrateStore())
+
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		assert.Equal(t, root, state_tree.NewStateTree(reader).GetRoot())
+		assert.Equal(t, uint64(storeVersion), loadStoreVersion(reader))
+		return
+	}))
+}
@@ -0,0 +1,187 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/config"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+//it avoids a huge update when pruning is enabled on an existing chain. The rest is pruned by pruneCatchUp
+const pruneMaxBlocksPerUpdate = 100
+
+//all the blocks below the pruned height have their transactions discarded. The headers are kept
+func LoadPrunedHeight(reader store_db_interface.StoreDBTransactionInterface) uint64 {
+	if data := reader.Get("chainPrunedHeight"); data != nil {
+		prunedHeight, _ := binary.Uvarint(data)
+		return prunedHeight
+	}
+	return 0
+}
+
+func savePrunedHeight(writer store_db_interface.StoreDBTransactionInterface, prunedHeight uint64) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, prunedHeight)
+	writer.Put("chainPrunedHeight", buf[:n])
+}
+
+func pruneTxInfo(writer store_db_interface.StoreDBTransactionInterface, txHash string) (err error) {
+
+	if data := writer.Get("txInfo_ByHash" + txHash); data != nil {
+		txInfo := &info.TxInfo{}
+		if err = msgpack.Unmarshal(data, txInfo); err != nil {
+			return
+		}
+		writer.Delete("txHash_ByHeight" + helpers.FormatIndexKey(txInfo.Height))
+	}
+	writer.Delete("txInfo_ByHash" + txHash)
+	writer.Delete("txPreview_ByHash" + txHash)
+
+	data := writer.Get("txKeys:" + txHash)
+	if data == nil {
+		return
+	}
+
+	keys := make([][]byte, 0)
+	if err = msgpack.Unmarshal(data, &keys); err != nil {
+		return
+	}
+
+	//the txs are pruned in the order they were included, so it is always the oldest entry of the key
+	for _, key := range keys {
+
+		var oldest string
+		if err = writer.Iterate("addrTx:"+string(key)+":", "", false, func(key string, value []byte) (bool, error) {
+			if string(value) != txHash {
+				return false, errors.New("addrTx: pruned entry is not matching")
+			}
+			oldest = key
+			return false, nil
+		}); err != nil {
+			return
+		}
+		if oldest == "" {
+			return errors.New("addrTx: pruned entry was not found")
+		}
+
+		writer.Delete(oldest)
+	}
+
+	writer.Delete("txKeys:" + txHash)
+
+	return
+}
+
+func (chain *Blockchain) pruneBlockComplete(writer store_db_interface.StoreDBTransactionInterface, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	blockHeightStr := strconv.FormatUint(blockHeight, 10)
+
+	if err = dataStorage.DeleteTransitionalChangesFromStore(blockHeightStr); err != nil {
+		return
+	}
+
+	if hash := writer.Get("blockHash_ByHeight" + blockHeightStr); hash != nil {
+		writer.Delete("blockInfo_ByHash" + string(hash))
+	}
+
+	data := writer.Get("blockTxs" + blockHeightStr)
+	if data == nil {
+		return errors.New("blockTxs was not found")
+	}
+
+	txHashes := [][]byte{}
+	if err = msgpack.Unmarshal(data, &txHashes); err != nil {
+		return
+	}
+
+	//txHash: and txBlock: are kept to detect the already included txs and to report the pruned lookups
+	for _, txHash := range txHashes {
+		writer.Delete("tx:" + string(txHash))
+		if err = pruneTxInfo(writer, string(txHash)); err != nil {
+			return
+		}
+	}
+
+	writer.Delete("blockTxs" + blockHeightStr)
+
+	return
+}
+
+//it keeps the complete data only for the last config.PRUNE_BLOCKS blocks. It returns true when the pruned height reached the horizon
+func (chain *Blockchain) pruneBlocksComplete(writer store_db_interface.StoreDBTransactionInterface, chainHeight uint64, dataStorage *data_storage.DataStorage) (done bool, err error) {
+
+	if chainHeight <= config.PRUNE_BLOCKS {
+		return true, nil
+	}
+
+	prunedHeight := LoadPrunedHeight(writer)
+	horizon := chainHeight - config.PRUNE_BLOCKS
+	if prunedHeight >= horizon {
+		return true, nil
+	}
+
+	done = true
+	if horizon > prunedHeight+pruneMaxBlocksPerUpdate {
+		horizon = prunedHeight + pruneMaxBlocksPerUpdate
+		done = false
+	}
+
+	for height := prunedHeight; height < horizon; height++ {
+		if err = chain.pruneBlockComplete(writer, height, dataStorage); err != nil {
+			return
+		}
+	}
+
+	savePrunedHeight(writer, horizon)
+
+	return
+}
+
+//the pruning has its own update, so an error only rolls back the pruning and the blocks are still included
+func (chain *Blockchain) pruneBlocks() (done bool, err error) {
+	err = store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		done, err = chain.pruneBlocksComplete(writer, chain.GetChainData().Height, data_storage.NewDataStorage(writer))
+		return
+	})
+	return
+}
+
+//a chain that was not pruned before is pruned in the background. The lock is released after every update so the new blocks are not delayed
+func (chain *Blockchain) pruneCatchUp() {
+	for updates := 0; ; updates++ {
+
+		chain.mutex.Lock()
+		done, err := chain.pruneBlocks()
+		chain.mutex.Unlock()
+
+		if err != nil {
+			gui.GUI.Error("Error pruning the blocks", err)
+			return
+		}
+		if done {
+			//a large pruning leaves a lot of deleted keys
+			if updates > 0 {
+				compactStore()
+			}
+			return
+		}
+	}
+}
+
+func compactStore() {
+	compactor, ok := store.StoreBlockchain.DB.(store_db_interface.StoreDBCompactInterface)
+	if !ok {
+		return
+	}
+	gui.GUI.Info("Compacting the store...")
+	if err := compactor.Compact(); err != nil {
+		gui.GUI.Error("Error compacting the store", err)
+	}
+}
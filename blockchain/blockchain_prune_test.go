@@ -0,0 +1,195 @@
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/info"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+//every block has one tx of the same key
+func createTestPruneBlocks(t *testing.T, db store_db_interface.StoreDBInterface, count uint64) (txHashes [][]byte) {
+
+	key := cryptography.RandomHash()
+
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		for height := uint64(0); height < count; height++ {
+
+			heightStr := strconv.FormatUint(height, 10)
+			txHash := cryptography.RandomHash()
+			txHashes = append(txHashes, txHash)
+
+			writer.Put("blockHash_ByHeight"+heightStr, cryptography.RandomHash())
+			writer.Put("blockTxs"+heightStr, msgpackBytes(t, [][]byte{txHash}))
+			writer.Put("dataStorage:transitionsCollectionsKeys:"+heightStr, msgpackBytes(t, map[string]any{}))
+
+			writer.Put("tx:"+string(txHash), []byte{1})
+			writer.Put("txHash:"+string(txHash), []byte{1})
+			writer.Put("txInfo_ByHash"+string(txHash), msgpackBytes(t, &info.TxInfo{Height: height}))
+			writer.Put("txHash_ByHeight"+helpers.FormatIndexKey(height), txHash)
+			writer.Put("txKeys:"+string(txHash), msgpackBytes(t, [][]byte{key}))
+			writer.Put("addrTx:"+string(key)+":"+helpers.FormatIndexKey(height), txHash)
+		}
+		return
+	}))
+
+	return
+}
+
+func msgpackBytes(t *testing.T, data any) []byte {
+	out, err := msgpack.Marshal(data)
+	assert.Nil(t, err)
+	return out
+}
+
+func pruneTestBlocks(db store_db_interface.StoreDBInterface, chainHeight uint64) (done bool, err error) {
+	err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		done, err = (&Blockchain{}).pruneBlocksComplete(writer, chainHeight, data_storage.NewDataStorage(writer))
+		return
+	})
+	return
+}
+
+func TestPruneBlocksComplete(t *testing.T) {
+
+	pruneBlocks := config.PRUNE_BLOCKS
+	config.PRUNE_BLOCKS = 10
+	defer func() {
+		config.PRUNE_BLOCKS = pruneBlocks
+	}()
+
+	db, err := store_db_memory.CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+
+	count := uint64(pruneMaxBlocksPerUpdate + 30)
+	txHashes := createTestPruneBlocks(t, db, count)
+
+	//the first update is limited
+	done, err := pruneTestBlocks(db, count)
+	assert.Nil(t, err)
+	assert.False(t, done)
+
+	done, err = pruneTestBlocks(db, count)
+	assert.Nil(t, err)
+	assert.True(t, done)
+
+	horizon := count - config.PRUNE_BLOCKS
+
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		assert.Equal(t, horizon, LoadPrunedHeight(reader))
+
+		for height, txHash := range txHashes {
+			pruned := uint64(height) < horizon
+			assert.Equal(t, !pruned, reader.Exists("tx:"+string(txHash)))
+			assert.Equal(t, !pruned, reader.Exists("blockTxs"+strconv.Itoa(height)))
+			assert.Equal(t, !pruned, reader.Exists("txInfo_ByHash"+string(txHash)))
+			assert.Equal(t, !pruned, reader.Exists("txHash_ByHeight"+helpers.FormatIndexKey(uint64(height))))
+			assert.True(t, reader.Exists("txHash:"+string(txHash)))
+			assert.True(t, reader.Exists("blockHash_ByHeight"+strconv.Itoa(height)))
+		}
+
+		return
+	}))
+
+	//nothing is left to prune
+	done, err = pruneTestBlocks(db, count)
+	assert.Nil(t, err)
+	assert.True(t, done)
+
+}
+
+func TestPruneBlocksComplete_Rollback(t *testing.T) {
+
+	pruneBlocks := config.PRUNE_BLOCKS
+	config.PRUNE_BLOCKS = 10
+	defer func() {
+		config.PRUNE_BLOCKS = pruneBlocks
+	}()
+
+	db, err := store_db_memory.CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+
+	txHashes := createTestPruneBlocks(t, db, 20)
+
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		writer.Delete("blockTxs5")
+		return
+	}))
+
+	_, err = pruneTestBlocks(db, 20)
+	assert.NotNil(t, err)
+
+	//the blocks before the missing one are not pruned either
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		assert.Equal(t, uint64(0), LoadPrunedHeight(reader))
+		assert.True(t, reader.Exists("tx:"+string(txHashes[0])))
+		return
+	}))
+
+}
+
+type testCompactStore struct {
+	*store_db_memory.StoreDBMemory
+	compacted int
+}
+
+func (store *testCompactStore) Compact() error {
+	store.compacted += 1
+	return nil
+}
+
+func TestPruneCatchUp_Compact(t *testing.T) {
+
+	var err error
+	if gui.GUI == nil {
+		gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+		assert.Nil(t, err)
+	}
+
+	pruneBlocks := config.PRUNE_BLOCKS
+	config.PRUNE_BLOCKS = 10
+	defer func() {
+		config.PRUNE_BLOCKS = pruneBlocks
+	}()
+
+	memory, err := store_db_memory.CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+	db := &testCompactStore{memory, 0}
+
+	storeBlockchain := store.StoreBlockchain
+	store.StoreBlockchain = &store.Store{"blockchain", true, db}
+	defer func() {
+		store.StoreBlockchain = storeBlockchain
+	}()
+
+	count := uint64(pruneMaxBlocksPerUpdate + 30)
+	createTestPruneBlocks(t, db, count)
+
+	chain := &Blockchain{ChainData: &generics.Value[*BlockchainData]{}, mutex: &sync.Mutex{}}
+	chain.ChainData.Store(&BlockchainData{Height: count})
+
+	//the store is compacted only after a large pruning
+	chain.pruneCatchUp()
+	assert.Equal(t, 1, db.compacted)
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		assert.Equal(t, count-config.PRUNE_BLOCKS, LoadPrunedHeight(reader))
+		return
+	}))
+
+	chain.ChainData.Store(&BlockchainData{Height: count + 5})
+	chain.pruneCatchUp()
+	assert.Equal(t, 1, db.compacted)
+}
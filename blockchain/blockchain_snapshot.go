@@ -0,0 +1,505 @@
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"golang.org/x/crypto/sha3"
+	"io"
+	"os"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+	"strings"
+)
+
+/**
+Snapshot file
+magic | version | network | block height | block hash | (key, value)* | empty key | SHA3 checksum of everything before
+*/
+
+const snapshotMagic = "PANDORA-SNAPSHOT"
+const snapshotVersion = 0
+
+var (
+	snapshotImportChunkKeys = 10000 //keys committed by an update of the import
+	errSnapshotDiscarded    = errors.New("Snapshot changes are discarded")
+)
+
+type snapshotWriter struct {
+	w *bufio.Writer
+}
+
+func (writer *snapshotWriter) writeUvarint(value uint64) (err error) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, value)
+	_, err = writer.w.Write(buf[:n])
+	return
+}
+
+func (writer *snapshotWriter) writeBytes(value []byte) (err error) {
+	if err = writer.writeUvarint(uint64(len(value))); err != nil {
+		return
+	}
+	_, err = writer.w.Write(value)
+	return
+}
+
+type snapshotReader struct {
+	r *bufio.Reader
+}
+
+func (reader *snapshotReader) readBytes(maxLength uint64) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader.r)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxLength {
+		return nil, errors.New("Snapshot entry is too big")
+	}
+	out := make([]byte, length)
+	if _, err = io.ReadFull(reader.r, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+//the transitions and the bodies of the blocks older than the horizon are not exported
+func getSnapshotSkippedKeys(reader store_db_interface.StoreDBTransactionInterface, horizon uint64) (map[string]bool, error) {
+
+	skipped := make(map[string]bool)
+	for height := uint64(0); height < horizon; height++ {
+
+		heightStr := strconv.FormatUint(height, 10)
+
+		data := reader.Get("blockTxs" + heightStr)
+		if data == nil {
+			continue
+		}
+		skipped["blockTxs"+heightStr] = true
+
+		txHashes := [][]byte{}
+		if err := msgpack.Unmarshal(data, &txHashes); err != nil {
+			return nil, err
+		}
+		for _, txHash := range txHashes {
+			skipped["tx:"+string(txHash)] = true
+			skipped["txHash:"+string(txHash)] = true
+			skipped["txBlock:"+string(txHash)] = true
+		}
+	}
+
+	return skipped, nil
+}
+
+func isSnapshotSkippedTransition(key string, horizon uint64) bool {
+	index := strings.LastIndex(key, ":transitions")
+	if index == -1 {
+		return false
+	}
+	suffix := key[index+len(":transitions"):]
+	if strings.HasPrefix(suffix, "CollectionsKeys") {
+		suffix = suffix[len("CollectionsKeys"):]
+	}
+	if !strings.HasPrefix(suffix, ":") {
+		return false
+	}
+	height, err := strconv.ParseUint(suffix[1:], 10, 64)
+	return err == nil && height < horizon
+}
+
+//it removes the blocks above the height the same way AddBlocks does for a fork. The changes must never be committed
+func (chain *Blockchain) rollbackSnapshotChain(writer store_db_interface.StoreDBTransactionInterface, chainData *BlockchainData, height uint64) (newChainData *BlockchainData, err error) {
+
+	dataStorage := data_storage.NewDataStorage(writer)
+
+	removedTxHashes := make(map[string][]byte)
+	allTransactionsChanges := []*blockchain_types.BlockchainTransactionUpdate{}
+
+	for index := chainData.Height - 1; index > height; index-- {
+		if allTransactionsChanges, err = chain.removeBlockComplete(writer, index, removedTxHashes, allTransactionsChanges, dataStorage); err != nil {
+			return
+		}
+	}
+
+	newChainData = &BlockchainData{}
+	if err = newChainData.loadBlockchainInfo(writer, height+1); err != nil {
+		return
+	}
+
+	if err = dataStorage.CommitChanges(); err != nil {
+		return
+	}
+
+	for index := chainData.Height - 1; index > height; index-- {
+		if err = chain.deleteUnusedBlocksComplete(writer, index, dataStorage); err != nil {
+			return
+		}
+		writer.Delete("blockchainInfo_" + strconv.FormatUint(index+1, 10))
+		writer.Delete("totalDifficulty" + strconv.FormatUint(index+1, 10))
+	}
+
+	for txHash := range removedTxHashes {
+		writer.Delete("tx:" + txHash)
+		writer.Delete("txHash:" + txHash)
+		writer.Delete("txBlock:" + txHash)
+	}
+
+	if config.NODE_PROVIDE_EXTENDED_INFO_APP {
+		if err = removeUnusedTransactions(writer, newChainData.TransactionsCount, chainData.TransactionsCount); err != nil {
+			return
+		}
+		removeTxsInfo(writer, removedTxHashes)
+	}
+
+	if err = chain.saveBlockchainHashmaps(dataStorage); err != nil {
+		return
+	}
+
+	newChainData.saveBlockchainHeight(writer)
+	err = newChainData.saveBlockchain(writer)
+	return
+}
+
+func writeSnapshot(reader store_db_interface.StoreDBTransactionInterface, writer *snapshotWriter, chainData *BlockchainData) (count int, err error) {
+
+	var horizon uint64
+	if chainData.Height > config.SNAPSHOT_BLOCKS {
+		horizon = chainData.Height - config.SNAPSHOT_BLOCKS
+	}
+
+	skipped, err := getSnapshotSkippedKeys(reader, horizon)
+	if err != nil {
+		return
+	}
+
+	if _, err = writer.w.Write([]byte(snapshotMagic)); err != nil {
+		return
+	}
+	if err = writer.writeUvarint(snapshotVersion); err != nil {
+		return
+	}
+	if err = writer.writeUvarint(config.NETWORK_SELECTED); err != nil {
+		return
+	}
+	if err = writer.writeUvarint(chainData.Height - 1); err != nil {
+		return
+	}
+	if err = writer.writeBytes(chainData.Hash); err != nil {
+		return
+	}
+
+	if err = reader.Iterate("", "", false, func(key string, value []byte) (bool, error) {
+		if skipped[key] || isSnapshotSkippedTransition(key, horizon) || key == "chainPrunedHeight" {
+			return true, nil
+		}
+		if err := writer.writeBytes([]byte(key)); err != nil {
+			return false, err
+		}
+		count += 1
+		return true, writer.writeBytes(value)
+	}); err != nil {
+		return
+	}
+
+	//the imported node will refuse the lookups of the skipped blocks
+	if prunedHeight := LoadPrunedHeight(reader); prunedHeight > horizon {
+		horizon = prunedHeight
+	}
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, horizon)
+	if err = writer.writeBytes([]byte("chainPrunedHeight")); err != nil {
+		return
+	}
+	if err = writer.writeBytes(buf[:n]); err != nil {
+		return
+	}
+
+	err = writer.writeBytes(nil)
+	return
+}
+
+//the blocks above the height are rolled back in an update that is discarded
+func (chain *Blockchain) exportSnapshot(db store_db_interface.StoreDBInterface, path string, height uint64) (count int, err error) {
+
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	hasher := sha3.New256()
+	writer := &snapshotWriter{bufio.NewWriter(io.MultiWriter(file, hasher))}
+
+	var exportErr error
+	if err = db.Update(func(dbTx store_db_interface.StoreDBTransactionInterface) error {
+
+		exportErr = func() (err error) {
+
+			chainInfoData := dbTx.Get("blockchainInfo")
+			if chainInfoData == nil {
+				return errors.New("Chain not found")
+			}
+
+			chainData := &BlockchainData{}
+			if err = msgpack.Unmarshal(chainInfoData, chainData); err != nil {
+				return
+			}
+			if height >= chainData.Height {
+				return errors.New("Snapshot height is not in the chain")
+			}
+
+			if height+1 < chainData.Height {
+				if height < LoadPrunedHeight(dbTx) {
+					return errors.New("Snapshot height was pruned")
+				}
+				if chainData, err = chain.rollbackSnapshotChain(dbTx, chainData, height); err != nil {
+					return
+				}
+			}
+
+			count, err = writeSnapshot(dbTx, writer, chainData)
+			return
+		}()
+
+		return errSnapshotDiscarded
+	}); err != nil && err != errSnapshotDiscarded {
+		return
+	}
+	if err = exportErr; err != nil {
+		return
+	}
+
+	if err = writer.w.Flush(); err != nil {
+		return
+	}
+	_, err = file.Write(hasher.Sum(nil))
+	return
+}
+
+//height is the last block of the snapshot
+func (chain *Blockchain) ExportSnapshot(path string, height uint64) error {
+
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+
+	count, err := chain.exportSnapshot(store.StoreBlockchain.DB, path, height)
+	if err != nil {
+		return err
+	}
+
+	gui.GUI.Info("Snapshot exported at height " + strconv.FormatUint(height, 10) + " with " + strconv.Itoa(count) + " keys")
+	return nil
+}
+
+func verifySnapshotChecksum(file *os.File) (err error) {
+
+	info, err := file.Stat()
+	if err != nil {
+		return
+	}
+	if info.Size() < cryptography.HashSize {
+		return errors.New("Snapshot file is too small")
+	}
+
+	hasher := sha3.New256()
+	if _, err = io.CopyN(hasher, file, info.Size()-cryptography.HashSize); err != nil {
+		return
+	}
+
+	checksum := make([]byte, cryptography.HashSize)
+	if _, err = io.ReadFull(file, checksum); err != nil {
+		return
+	}
+	if !bytes.Equal(checksum, hasher.Sum(nil)) {
+		return errors.New("Snapshot checksum is invalid")
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	return
+}
+
+func getSnapshotExpectedHash(expectedHash string) ([]byte, error) {
+
+	if expectedHash == "" {
+		return nil, errors.New("Snapshot block hash can not be verified. --snapshot-hash was not specified")
+	}
+
+	hash, err := hex.DecodeString(expectedHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) != cryptography.HashSize {
+		return nil, errors.New("Snapshot hash is invalid")
+	}
+
+	return hash, nil
+}
+
+//it verifies the last block of the snapshot against the expected hash and the state against the committed root
+func verifySnapshotStore(writer store_db_interface.StoreDBTransactionInterface, hash []byte) (err error) {
+
+	if !bytes.Equal(writer.Get("chainHash"), hash) {
+		return errors.New("Snapshot chain hash doesn't match")
+	}
+
+	data := writer.Get("block_ByHash" + string(hash))
+	if data == nil {
+		return errors.New("Snapshot last block was not found")
+	}
+
+	blk := block.CreateEmptyBlock()
+	if err = blk.Deserialize(advanced_buffers.NewBufferReader(data)); err != nil {
+		return
+	}
+	if !bytes.Equal(blk.Bloom.Hash, hash) {
+		return errors.New("Snapshot last block hash doesn't match")
+	}
+
+	if blk.Version == block.BLOCK_VERSION_STATE_ROOT && !bytes.Equal(state_tree.NewStateTree(writer).GetRoot(), blk.StateRoot) {
+		return errors.New("Snapshot state doesn't match the StateRoot")
+	}
+
+	return
+}
+
+//the keys are committed in chunks. blockchainInfo is written last, so an interrupted import is never loaded as a chain
+func (chain *Blockchain) importSnapshot(db store_db_interface.StoreDBInterface, path, expectedHash string) (height uint64, count int, err error) {
+
+	expected, err := getSnapshotExpectedHash(expectedHash)
+	if err != nil {
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	if err = verifySnapshotChecksum(file); err != nil {
+		return
+	}
+
+	reader := &snapshotReader{bufio.NewReader(file)}
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err = io.ReadFull(reader.r, magic); err != nil {
+		return
+	}
+	if string(magic) != snapshotMagic {
+		err = errors.New("File is not a snapshot")
+		return
+	}
+
+	var version, network uint64
+	if version, err = binary.ReadUvarint(reader.r); err != nil {
+		return
+	}
+	if version != snapshotVersion {
+		err = errors.New("Snapshot version is not supported")
+		return
+	}
+	if network, err = binary.ReadUvarint(reader.r); err != nil {
+		return
+	}
+	if network != config.NETWORK_SELECTED {
+		err = errors.New("Snapshot is for a different network")
+		return
+	}
+	if height, err = binary.ReadUvarint(reader.r); err != nil {
+		return
+	}
+
+	hash, err := reader.readBytes(cryptography.HashSize)
+	if err != nil {
+		return
+	}
+	if !bytes.Equal(hash, expected) {
+		err = errors.New("Snapshot block hash is not the expected one")
+		return
+	}
+
+	if err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		if writer.Exists("snapshotImport") {
+			return errors.New("A previous snapshot import was interrupted. The blockchain store must be deleted")
+		}
+		if writer.Exists("blockchainInfo") {
+			return errors.New("Blockchain store is not empty")
+		}
+		writer.Put("snapshotImport", hash)
+		return nil
+	}); err != nil {
+		return
+	}
+
+	done := false
+	var chainInfoData []byte
+
+	for !done {
+		if err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			var key, value []byte
+			for i := 0; i < snapshotImportChunkKeys; i++ {
+				if key, err = reader.readBytes(1024); err != nil {
+					return
+				}
+				if len(key) == 0 {
+					done = true
+					return
+				}
+				if value, err = reader.readBytes(config.BLOCK_MAX_SIZE * 2); err != nil {
+					return
+				}
+				if string(key) == "blockchainInfo" {
+					chainInfoData = value
+				} else {
+					writer.Put(string(key), value)
+				}
+				count += 1
+			}
+
+			return
+		}); err != nil {
+			return
+		}
+	}
+
+	if chainInfoData == nil {
+		err = errors.New("Snapshot has no chain")
+		return
+	}
+
+	err = db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		if err = verifySnapshotStore(writer, hash); err != nil {
+			return
+		}
+		writer.Put("blockchainInfo", chainInfoData)
+		writer.Delete("snapshotImport")
+		return
+	})
+	return
+}
+
+//it must be called before the chain is initialized
+func (chain *Blockchain) ImportSnapshot(path, expectedHash string) error {
+
+	height, count, err := chain.importSnapshot(store.StoreBlockchain.DB, path, expectedHash)
+	if err != nil {
+		return err
+	}
+
+	gui.GUI.Info("Snapshot imported at height " + strconv.FormatUint(height, 10) + " with " + strconv.Itoa(count) + " keys")
+	return nil
+}
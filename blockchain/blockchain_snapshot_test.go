@@ -0,0 +1,216 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/config"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+//every block creates a plain account and includes one tx
+func createTestSnapshotChain(t *testing.T, db store_db_interface.StoreDBInterface, count uint64) (hashes, txHashes, publicKeys [][]byte) {
+
+	chainData := &BlockchainData{
+		Hash:               cryptography.RandomHash(),
+		KernelHash:         cryptography.RandomHash(),
+		Target:             big.NewInt(1),
+		BigTotalDifficulty: big.NewInt(0),
+	}
+
+	for height := uint64(0); height < count; height++ {
+		assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			heightStr := strconv.FormatUint(height, 10)
+
+			dataStorage := data_storage.NewDataStorage(writer)
+			publicKey := helpers.RandomBytes(cryptography.PublicKeySize)
+			publicKeys = append(publicKeys, publicKey)
+			plainAcc, err := dataStorage.CreatePlainAccount(publicKey, false)
+			assert.Nil(t, err)
+			assert.Nil(t, plainAcc.AddUnclaimed(true, 1))
+			assert.Nil(t, dataStorage.PlainAccs.Update(string(publicKey), plainAcc))
+
+			assert.Nil(t, dataStorage.WriteStateTree())
+			stateRoot := dataStorage.StateTree.GetRoot()
+			assert.Nil(t, dataStorage.WriteTransitionalChangesToStore(heightStr))
+			assert.Nil(t, dataStorage.CommitChanges())
+
+			blk, err := newTestHeader(chainData, func(blk *block.Block) {
+				blk.Version = block.BLOCK_VERSION_STATE_ROOT
+				blk.StateRoot = stateRoot
+			})
+			assert.Nil(t, err)
+			hashes = append(hashes, blk.Bloom.Hash)
+
+			txHash := cryptography.RandomHash()
+			txHashes = append(txHashes, txHash)
+
+			writer.Put("block_ByHash"+string(blk.Bloom.Hash), helpers.SerializeToBytes(blk))
+			writer.Put("blockHash_ByHeight"+heightStr, blk.Bloom.Hash)
+			writer.Put("blockKernelHash_ByHeight"+heightStr, blk.Bloom.KernelHash)
+			writer.Put("blockHeight_ByHash"+string(blk.Bloom.Hash), []byte(heightStr))
+			writer.Put("blockTxs"+heightStr, msgpackBytes(t, [][]byte{txHash}))
+			writer.Put("tx:"+string(txHash), []byte{1})
+			writer.Put("txHash:"+string(txHash), []byte{1})
+			writer.Put("txBlock:"+string(txHash), []byte{1})
+
+			chainData.PrevHash, chainData.Hash = chainData.Hash, blk.Bloom.Hash
+			chainData.PrevKernelHash, chainData.KernelHash = chainData.KernelHash, blk.Bloom.KernelHash
+			chainData.Height += 1
+			chainData.Timestamp = blk.Timestamp
+			chainData.BigTotalDifficulty = new(big.Int).Add(chainData.BigTotalDifficulty, big.NewInt(1))
+			chainData.TransactionsCount += 1
+
+			chainData.saveTotalDifficultyExtra(writer)
+			chainData.saveBlockchainHeight(writer)
+			assert.Nil(t, chainData.saveBlockchainInfo(writer))
+			return chainData.saveBlockchain(writer)
+		}))
+	}
+
+	return
+}
+
+func loadTestSnapshotChainData(t *testing.T, db store_db_interface.StoreDBInterface) (chainData *BlockchainData) {
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		data := reader.Get("blockchainInfo")
+		if data == nil {
+			return nil
+		}
+		chainData = &BlockchainData{}
+		return msgpack.Unmarshal(data, chainData)
+	}))
+	return
+}
+
+func exportTestSnapshot(db store_db_interface.StoreDBInterface, path string, height uint64) (err error) {
+	_, err = (&Blockchain{}).exportSnapshot(db, path, height)
+	return
+}
+
+func importTestSnapshot(db store_db_interface.StoreDBInterface, path, expectedHash string) (err error) {
+	_, _, err = (&Blockchain{}).importSnapshot(db, path, expectedHash)
+	return
+}
+
+func TestSnapshot(t *testing.T) {
+
+	chunkKeys := snapshotImportChunkKeys
+	snapshotImportChunkKeys = 7
+	defer func() {
+		snapshotImportChunkKeys = chunkKeys
+	}()
+
+	db, err := store_db_memory.CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+
+	hashes, txHashes, publicKeys := createTestSnapshotChain(t, db, config.SNAPSHOT_BLOCKS+10)
+
+	tip := config.SNAPSHOT_BLOCKS + 9
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+
+	//the tip
+	assert.Nil(t, exportTestSnapshot(db, path, tip))
+
+	db2, err := store_db_memory.CreateStoreDBMemory("/test2")
+	assert.Nil(t, err)
+
+	assert.NotNil(t, importTestSnapshot(db2, path, ""))
+	assert.NotNil(t, importTestSnapshot(db2, path, hex.EncodeToString(hashes[tip-1])))
+	assert.Nil(t, importTestSnapshot(db2, path, hex.EncodeToString(hashes[tip])))
+	assert.Equal(t, loadTestSnapshotChainData(t, db), loadTestSnapshotChainData(t, db2))
+
+	assert.Nil(t, db2.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, uint64(10), LoadPrunedHeight(reader))
+		assert.False(t, reader.Exists("tx:"+string(txHashes[9])))
+		assert.False(t, reader.Exists("blockTxs9"))
+		assert.True(t, reader.Exists("tx:"+string(txHashes[10])))
+		assert.True(t, reader.Exists("blockHash_ByHeight0"))
+		assert.False(t, reader.Exists("snapshotImport"))
+		return nil
+	}))
+
+	//the store is not empty anymore
+	assert.NotNil(t, importTestSnapshot(db2, path, hex.EncodeToString(hashes[tip])))
+
+	//a past height
+	assert.NotNil(t, exportTestSnapshot(db, path, tip+1))
+	assert.Nil(t, exportTestSnapshot(db, path, tip-2))
+
+	db3, err := store_db_memory.CreateStoreDBMemory("/test3")
+	assert.Nil(t, err)
+	assert.NotNil(t, importTestSnapshot(db3, path, hex.EncodeToString(hashes[tip])))
+	assert.Nil(t, importTestSnapshot(db3, path, hex.EncodeToString(hashes[tip-2])))
+
+	chainData := loadTestSnapshotChainData(t, db3)
+	assert.Equal(t, tip-1, chainData.Height)
+	assert.Equal(t, hashes[tip-2], chainData.Hash)
+
+	assert.Nil(t, db3.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.Equal(t, uint64(8), LoadPrunedHeight(reader))
+		assert.False(t, reader.Exists("blockHash_ByHeight"+strconv.FormatUint(tip-1, 10)))
+		assert.False(t, reader.Exists("block_ByHash"+string(hashes[tip-1])))
+		assert.False(t, reader.Exists("tx:"+string(txHashes[tip-1])))
+		assert.False(t, reader.Exists("txHash:"+string(txHashes[tip])))
+		assert.False(t, reader.Exists("blockchainInfo_"+strconv.FormatUint(tip, 10)))
+		assert.True(t, reader.Exists("tx:"+string(txHashes[tip-2])))
+
+		exists, err := data_storage.NewDataStorage(reader).PlainAccs.Exists(string(publicKeys[tip-1]))
+		assert.Nil(t, err)
+		assert.False(t, exists)
+		exists, err = data_storage.NewDataStorage(reader).PlainAccs.Exists(string(publicKeys[tip-2]))
+		assert.Nil(t, err)
+		assert.True(t, exists)
+		return nil
+	}))
+
+	//the rollback of the export is discarded
+	assert.Equal(t, tip+1, loadTestSnapshotChainData(t, db).Height)
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		assert.True(t, reader.Exists("blockHash_ByHeight"+strconv.FormatUint(tip, 10)))
+		assert.True(t, reader.Exists("tx:"+string(txHashes[tip])))
+		return nil
+	}))
+
+	//a corrupted file is rejected
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	data[len(snapshotMagic)+10] ^= 1
+	assert.Nil(t, os.WriteFile(path, data, 0644))
+
+	db4, err := store_db_memory.CreateStoreDBMemory("/test4")
+	assert.Nil(t, err)
+	assert.NotNil(t, importTestSnapshot(db4, path, hex.EncodeToString(hashes[tip-2])))
+}
+
+func TestSnapshot_Interrupted(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+	hashes, _, _ := createTestSnapshotChain(t, db, 3)
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	assert.Nil(t, exportTestSnapshot(db, path, 2))
+
+	db2, err := store_db_memory.CreateStoreDBMemory("/test2")
+	assert.Nil(t, err)
+	assert.Nil(t, db2.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("snapshotImport", hashes[2])
+		return nil
+	}))
+
+	assert.NotNil(t, importTestSnapshot(db2, path, hex.EncodeToString(hashes[2])))
+	assert.Nil(t, loadTestSnapshotChainData(t, db2))
+}
@@ -0,0 +1,32 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/blocks/block"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/data_storage"
+)
+
+//the forged blocks are created before the state is known, so the state root is set once the changes are written in the state tree
+func (chain *Blockchain) processBlockStateRoot(blkComplete *block_complete.BlockComplete, dataStorage *data_storage.DataStorage, calledByForging bool) error {
+
+	if blkComplete.Block.Version != block.BLOCK_VERSION_STATE_ROOT {
+		return nil
+	}
+
+	stateRoot := dataStorage.StateTree.GetRoot()
+	if bytes.Equal(blkComplete.Block.StateRoot, stateRoot) {
+		return nil
+	}
+
+	if !calledByForging {
+		return invalidBlock(errors.New("StateRoot doesn't match"))
+	}
+
+	blkComplete.Block.StateRoot = stateRoot
+	blkComplete.Block.Bloom = nil
+	blkComplete.BloomBlkComplete = nil
+
+	return blkComplete.BloomAll()
+}
@@ -126,10 +126,18 @@ func (chain *Blockchain) removeBlockComplete(writer store_db_interface.StoreDBTr
 	return allTransactionsChangesFinal, nil
 }
 
-func (chain *Blockchain) saveBlockComplete(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete, transactionsCount uint64, removedTxHashes map[string][]byte, allTransactionsChanges []*blockchain_types.BlockchainTransactionUpdate, dataStorage *data_storage.DataStorage) ([]*blockchain_types.BlockchainTransactionUpdate, error) {
+func (chain *Blockchain) saveBlockComplete(writer store_db_interface.StoreDBTransactionInterface, blkComplete *block_complete.BlockComplete, transactionsCount uint64, removedTxHashes map[string][]byte, allTransactionsChanges []*blockchain_types.BlockchainTransactionUpdate, dataStorage *data_storage.DataStorage, calledByForging bool) ([]*blockchain_types.BlockchainTransactionUpdate, error) {
 
 	allTransactionsChanges2 := allTransactionsChanges
 
+	//the state root is verified before anything is committed
+	if err := dataStorage.WriteStateTree(); err != nil {
+		return allTransactionsChanges, err
+	}
+	if err := chain.processBlockStateRoot(blkComplete, dataStorage, calledByForging); err != nil {
+		return allTransactionsChanges, err
+	}
+
 	blockHeightStr := strconv.FormatUint(blkComplete.Block.Height, 10)
 	if err := dataStorage.WriteTransitionalChangesToStore(blockHeightStr); err != nil {
 		return allTransactionsChanges, err
@@ -219,6 +227,9 @@ func (chain *Blockchain) loadBlockchain() error {
 
 		chainInfoData := reader.Get("blockchainInfo")
 		if chainInfoData == nil {
+			if reader.Exists("snapshotImport") {
+				return errors.New("A snapshot import was interrupted. The blockchain store must be deleted")
+			}
 			return errors.New("Chain not found")
 		}
 
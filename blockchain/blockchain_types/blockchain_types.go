@@ -32,6 +32,7 @@ type MempoolTransactionUpdate struct {
 	Tx                               *transaction.Transaction
 	IncludedInBlockchainNotification bool
 	Keys                             map[string]bool
+	FeePerByte                       uint64
 }
 
 type BlockchainUpdates struct {
@@ -41,6 +42,8 @@ type BlockchainUpdates struct {
 	Registrations  *registrations.Registrations
 	BlockHeight    uint64
 	BlockHash      []byte
+	InsertedBlocks []*block_complete.BlockComplete
+	RemovedTxs     [][]byte //hashes of the txs removed by a reorg and not included again
 }
 
 type BlockchainSolutionAnswer struct {
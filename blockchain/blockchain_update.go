@@ -68,6 +68,17 @@ func (queue *BlockchainUpdatesQueue) lastSuccess(updates []*BlockchainUpdate) *B
 	return nil
 }
 
+//the txs removed by a reorg that were not included again by the new blocks
+func (update *BlockchainUpdate) getRemovedTxs() [][]byte {
+	removedTxs := make([][]byte, 0, len(update.removedTxHashes))
+	for _, change := range update.allTransactionsChanges {
+		if !change.Inserted && update.removedTxHashes[change.TxHashStr] != nil && update.insertedTxs[change.TxHashStr] == nil {
+			removedTxs = append(removedTxs, change.TxHash)
+		}
+	}
+	return removedTxs
+}
+
 func (queue *BlockchainUpdatesQueue) executeUpdate(update *BlockchainUpdate) (err error) {
 
 	gui.GUI.Warning("-------------------------------------------")
@@ -83,6 +94,8 @@ func (queue *BlockchainUpdatesQueue) executeUpdate(update *BlockchainUpdate) (er
 		update.dataStorage.Regs,
 		update.newChainData.Height,
 		update.newChainData.Hash,
+		update.insertedBlocks,
+		update.getRemovedTxs(),
 	})
 
 	chainSyncData := queue.chain.Sync.AddBlocksChanged(uint32(len(update.insertedBlocks)), true)
@@ -0,0 +1,37 @@
+package blockchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/cryptography"
+	"testing"
+)
+
+func TestBlockchainUpdate_GetRemovedTxs(t *testing.T) {
+
+	hashes := make([][]byte, 4)
+	for i := range hashes {
+		hashes[i] = cryptography.RandomHash()
+	}
+
+	change := func(i int, inserted bool) *blockchain_types.BlockchainTransactionUpdate {
+		return &blockchain_types.BlockchainTransactionUpdate{TxHash: hashes[i], TxHashStr: string(hashes[i]), Inserted: inserted}
+	}
+
+	//the reorg removed the first three txs and the new blocks included the second one again and the last one
+	update := &BlockchainUpdate{
+		allTransactionsChanges: []*blockchain_types.BlockchainTransactionUpdate{change(0, false), change(1, false), change(2, false), change(1, true), change(3, true)},
+		removedTxHashes:        map[string][]byte{string(hashes[0]): hashes[0], string(hashes[1]): hashes[1], string(hashes[2]): hashes[2]},
+		insertedTxs:            map[string]*transaction.Transaction{string(hashes[1]): {}, string(hashes[3]): {}},
+	}
+
+	assert.Equal(t, [][]byte{hashes[0], hashes[2]}, update.getRemovedTxs())
+
+	//no reorg
+	update = &BlockchainUpdate{
+		allTransactionsChanges: []*blockchain_types.BlockchainTransactionUpdate{change(3, true)},
+		insertedTxs:            map[string]*transaction.Transaction{string(hashes[3]): {}},
+	}
+	assert.Equal(t, 0, len(update.getRemovedTxs()))
+}
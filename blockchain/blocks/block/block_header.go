@@ -2,21 +2,35 @@ package block
 
 import (
 	"errors"
+	"pandora-pay/config"
 	"pandora-pay/helpers/advanced_buffers"
 )
 
+const (
+	BLOCK_VERSION_INITIAL    uint64 = 0
+	BLOCK_VERSION_STATE_ROOT uint64 = 1
+)
+
 type BlockHeader struct {
 	Version uint64 `json:"version" msgpack:"version"`
 	Height  uint64 `json:"height" msgpack:"height"`
 }
 
 func (blockHeader *BlockHeader) Validate() error {
-	if blockHeader.Version != 0 {
+	if blockHeader.Version > BLOCK_VERSION_STATE_ROOT {
 		return errors.New("Invalid Block")
 	}
 	return nil
 }
 
+//the version expected at the height
+func GetBlockVersion(height uint64) uint64 {
+	if height >= config.NETWORK_SELECTED_FORKS.StateRoot {
+		return BLOCK_VERSION_STATE_ROOT
+	}
+	return BLOCK_VERSION_INITIAL
+}
+
 func (blockHeader *BlockHeader) Serialize(w *advanced_buffers.BufferWriter) {
 	w.WriteUvarint(blockHeader.Version)
 	w.WriteUvarint(blockHeader.Height)
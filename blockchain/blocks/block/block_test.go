@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"pandora-pay/addresses"
 	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/advanced_buffers"
 	"testing"
@@ -19,15 +20,13 @@ var (
 func TestBlock_Serialize(t *testing.T) {
 	var err error
 
-	privateKey := addresses.GenerateNewPrivateKey()
-	publicKey := privateKey.GeneratePublicKey()
-
 	blk := Block{
 		BlockHeader:    &BlockHeader{Version: 0, Height: 0},
 		MerkleHash:     merkleHash,
 		PrevHash:       prevHash,
 		PrevKernelHash: prevKernelHash,
 		Timestamp:      uint64(time.Now().Unix()),
+		StakingNonce:   make([]byte, 32),
 	}
 
 	buf := blk.SerializeManualToBytes()
@@ -43,6 +42,28 @@ func TestBlock_Serialize(t *testing.T) {
 
 }
 
+func TestBlock_SerializeStateRoot(t *testing.T) {
+
+	blk := Block{
+		BlockHeader:    &BlockHeader{Version: BLOCK_VERSION_STATE_ROOT, Height: 0},
+		MerkleHash:     merkleHash,
+		StateRoot:      cryptography.SHA3([]byte("StateRoot")),
+		PrevHash:       prevHash,
+		PrevKernelHash: prevKernelHash,
+		Timestamp:      uint64(time.Now().Unix()),
+		StakingNonce:   make([]byte, 32),
+	}
+
+	blk2 := &Block{BlockHeader: &BlockHeader{}}
+	assert.NoError(t, blk2.Deserialize(advanced_buffers.NewBufferReader(blk.SerializeManualToBytes())), "Error...?")
+	assert.Equal(t, blk.StateRoot, blk2.StateRoot, "StateRoot was not deserialized")
+
+	kernelHash := blk.ComputeKernelHash()
+	blk.StateRoot = cryptography.SHA3([]byte("StateRoot2"))
+	assert.Equal(t, kernelHash, blk.ComputeKernelHash(), "StateRoot should not change the kernel hash")
+
+}
+
 func TestBlock_SerializeForSigning(t *testing.T) {
 
 	var err error
@@ -67,4 +88,5 @@ func TestBlock_SerializeForSigning(t *testing.T) {
 	assert.NoError(t, err, "Signing raised an error")
 
 	assert.NotEqual(t, signature, helpers.EmptyBytes(cryptography.SignatureSize), "Invalid signature")
+	assert.True(t, crypto.VerifySignature(hash, signature, publicKey), "Signature is not matching the public key")
 }
@@ -0,0 +1,204 @@
+package ballot
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage/accounts/account/account_balance_homomorphic"
+	"pandora-pay/config/config_ballots"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type Ballot struct {
+	BallotId           []byte                                            `json:"-" msgpack:"-"` //hashmap key
+	Index              uint64                                            `json:"-" msgpack:"-"` //hashmap index
+	Version            uint64                                            `json:"version" msgpack:"version"`
+	Title              string                                            `json:"title" msgpack:"title"`
+	Options            []string                                          `json:"options" msgpack:"options"`
+	OptionsPublicKeys  [][]byte                                          `json:"optionsPublicKeys" msgpack:"optionsPublicKeys"`
+	EligibilityAsset   []byte                                            `json:"eligibilityAsset" msgpack:"eligibilityAsset"`
+	StartHeight        uint64                                            `json:"startHeight" msgpack:"startHeight"`
+	EndHeight          uint64                                            `json:"endHeight" msgpack:"endHeight"`
+	Creator            []byte                                            `json:"creator" msgpack:"creator"` //plain account that closes the ballot
+	Tallies            []*account_balance_homomorphic.BalanceHomomorphic `json:"tallies" msgpack:"tallies"`
+	Closed             bool                                              `json:"closed" msgpack:"closed"`
+	Results            []uint64                                          `json:"results,omitempty" msgpack:"results,omitempty"`
+	OptionsPrivateKeys [][]byte                                          `json:"optionsPrivateKeys,omitempty" msgpack:"optionsPrivateKeys,omitempty"` //revealed when closed to make the tally verifiable
+}
+
+func (this *Ballot) IsDeletable() bool {
+	return false
+}
+
+func (this *Ballot) SetKey(key []byte) {
+	this.BallotId = key
+}
+
+func (this *Ballot) SetIndex(value uint64) {
+	this.Index = value
+}
+
+func (this *Ballot) GetIndex() uint64 {
+	return this.Index
+}
+
+func (this *Ballot) GetOptionIndex(publicKey []byte) int {
+	for i, optionPublicKey := range this.OptionsPublicKeys {
+		if string(optionPublicKey) == string(publicKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (this *Ballot) Validate() error {
+	if this.Version != 0 {
+		return errors.New("Ballot Version is invalid")
+	}
+	if len(this.Title) == 0 || len(this.Title) > config_ballots.BALLOT_TITLE_MAX_LENGTH {
+		return errors.New("Ballot title length is invalid")
+	}
+	if len(this.Options) < config_ballots.BALLOT_OPTIONS_MIN || len(this.Options) > config_ballots.BALLOT_OPTIONS_MAX {
+		return errors.New("Ballot options length is invalid")
+	}
+	for _, option := range this.Options {
+		if len(option) == 0 || len(option) > config_ballots.BALLOT_OPTION_MAX_LENGTH {
+			return errors.New("Ballot option length is invalid")
+		}
+	}
+	if len(this.OptionsPublicKeys) != len(this.Options) || len(this.Tallies) != len(this.Options) {
+		return errors.New("Ballot options, public keys and tallies mismatch")
+	}
+
+	unique := make(map[string]bool)
+	for _, publicKey := range this.OptionsPublicKeys {
+		if len(publicKey) != cryptography.PublicKeySize {
+			return errors.New("Ballot option public key size is invalid")
+		}
+		unique[string(publicKey)] = true
+	}
+	if len(unique) != len(this.OptionsPublicKeys) {
+		return errors.New("Ballot options public keys contain duplicates")
+	}
+
+	if len(this.EligibilityAsset) != config_coins.ASSET_LENGTH {
+		return errors.New("Ballot eligibility asset is invalid")
+	}
+	if this.StartHeight >= this.EndHeight {
+		return errors.New("Ballot voting window is invalid")
+	}
+	if len(this.Creator) != cryptography.PublicKeySize {
+		return errors.New("Ballot creator is invalid")
+	}
+	if this.EndHeight-this.StartHeight > config_ballots.BALLOT_VOTING_WINDOW_MAX {
+		return errors.New("Ballot voting window is too long")
+	}
+
+	if this.Closed {
+		if len(this.Results) != len(this.Options) || len(this.OptionsPrivateKeys) != len(this.Options) {
+			return errors.New("Ballot results mismatch")
+		}
+		for _, privateKey := range this.OptionsPrivateKeys {
+			if len(privateKey) != cryptography.PrivateKeySize {
+				return errors.New("Ballot option private key size is invalid")
+			}
+		}
+	} else if len(this.Results) != 0 || len(this.OptionsPrivateKeys) != 0 {
+		return errors.New("Ballot results should be empty until it is closed")
+	}
+
+	return nil
+}
+
+func (this *Ballot) Serialize(w *advanced_buffers.BufferWriter) {
+	w.WriteUvarint(this.Version)
+	w.WriteString(this.Title)
+	w.WriteUvarint(uint64(len(this.Options)))
+	for i := range this.Options {
+		w.WriteString(this.Options[i])
+		w.Write(this.OptionsPublicKeys[i])
+		this.Tallies[i].Serialize(w)
+	}
+	w.WriteAsset(this.EligibilityAsset)
+	w.WriteUvarint(this.StartHeight)
+	w.WriteUvarint(this.EndHeight)
+	w.Write(this.Creator)
+	w.WriteBool(this.Closed)
+	if this.Closed {
+		for i := range this.Results {
+			w.WriteUvarint(this.Results[i])
+			w.Write(this.OptionsPrivateKeys[i])
+		}
+	}
+}
+
+func (this *Ballot) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if this.Version, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if this.Title, err = r.ReadString(uint64(config_ballots.BALLOT_TITLE_MAX_LENGTH)); err != nil {
+		return
+	}
+
+	var n uint64
+	if n, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if n < uint64(config_ballots.BALLOT_OPTIONS_MIN) || n > uint64(config_ballots.BALLOT_OPTIONS_MAX) {
+		return errors.New("Ballot has too many options")
+	}
+
+	this.Options = make([]string, n)
+	this.OptionsPublicKeys = make([][]byte, n)
+	this.Tallies = make([]*account_balance_homomorphic.BalanceHomomorphic, n)
+	for i := range this.Options {
+		if this.Options[i], err = r.ReadString(uint64(config_ballots.BALLOT_OPTION_MAX_LENGTH)); err != nil {
+			return
+		}
+		if this.OptionsPublicKeys[i], err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+		this.Tallies[i] = &account_balance_homomorphic.BalanceHomomorphic{}
+		if err = this.Tallies[i].Deserialize(r); err != nil {
+			return
+		}
+	}
+
+	if this.EligibilityAsset, err = r.ReadAsset(); err != nil {
+		return
+	}
+	if this.StartHeight, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if this.EndHeight, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if this.Creator, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if this.Closed, err = r.ReadBool(); err != nil {
+		return
+	}
+
+	if this.Closed {
+		this.Results = make([]uint64, n)
+		this.OptionsPrivateKeys = make([][]byte, n)
+		for i := range this.Results {
+			if this.Results[i], err = r.ReadUvarint(); err != nil {
+				return
+			}
+			if this.OptionsPrivateKeys[i], err = r.ReadBytes(cryptography.PrivateKeySize); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func NewBallot(key []byte, index uint64) *Ballot {
+	return &Ballot{
+		BallotId: key,
+		Index:    index,
+	}
+}
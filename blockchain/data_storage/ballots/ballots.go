@@ -0,0 +1,39 @@
+package ballots
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage/ballots/ballot"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type Ballots struct {
+	*hash_map.HashMap[*ballot.Ballot]
+}
+
+func (this *Ballots) CreateBallot(key []byte, b *ballot.Ballot) (err error) {
+
+	var exists bool
+	if exists, err = this.Exists(string(key)); err != nil {
+		return
+	}
+	if exists {
+		return errors.New("Ballot already exists")
+	}
+
+	return this.Update(string(key), b)
+}
+
+func NewBallots(tx store_db_interface.StoreDBTransactionInterface) (this *Ballots) {
+
+	this = &Ballots{
+		hash_map.CreateNewHashMap[*ballot.Ballot](tx, "ballots", config_coins.ASSET_LENGTH, true),
+	}
+
+	this.HashMap.CreateObject = func(key []byte, index uint64) (*ballot.Ballot, error) {
+		return ballot.NewBallot(key, index), nil
+	}
+
+	return
+}
@@ -1,13 +1,18 @@
 package data_storage
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"pandora-pay/blockchain/data_storage/accounts"
 	"pandora-pay/blockchain/data_storage/accounts/account"
 	"pandora-pay/blockchain/data_storage/assets"
+	"pandora-pay/blockchain/data_storage/ballots"
 	"pandora-pay/blockchain/data_storage/conditional_payments_list"
 	"pandora-pay/blockchain/data_storage/conditional_payments_list/conditional_payment"
+	"pandora-pay/blockchain/data_storage/identities"
+	"pandora-pay/blockchain/data_storage/identities/identity"
+	"pandora-pay/blockchain/data_storage/notarizations"
 	"pandora-pay/blockchain/data_storage/pending_stakes_list"
 	"pandora-pay/blockchain/data_storage/pending_stakes_list/pending_stakes"
 	"pandora-pay/blockchain/data_storage/plain_accounts"
@@ -18,6 +23,7 @@ import (
 	"pandora-pay/config/config_asset_fee"
 	"pandora-pay/config/config_coins"
 	"pandora-pay/cryptography/crypto"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 	"strconv"
 )
@@ -31,6 +37,11 @@ type DataStorage struct {
 	ConditionalPaymentsCollection *conditional_payments_list.ConditionalPaymentsCollection
 	Asts                          *assets.Assets
 	AstsFeeLiquidityCollection    *assets.AssetsFeeLiquidityCollection
+	Ballots                       *ballots.Ballots
+	Identities                    *identities.Identities
+	Notarizations                 *notarizations.Notarizations
+	StateTree                     *state_tree.StateTree
+	stateTreeWritten              bool //the pending changes were already written in the state tree
 }
 
 func (dataStorage *DataStorage) GetOrCreateAccount(assetId, publicKey []byte, validateRegistration bool) (*accounts.Accounts, *account.Account, error) {
@@ -358,6 +369,67 @@ func (dataStorage *DataStorage) ProcessConditionalPayments(blockHeight uint64) e
 	return nil
 }
 
+func (dataStorage *DataStorage) AddBallotVote(blockHeight uint64, ballotId, asset []byte, parity bool, publicKeyList [][]byte, echangesAll []*crypto.ElGamal) error {
+
+	b, err := dataStorage.Ballots.Get(string(ballotId))
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return errors.New("Ballot doesn't exist")
+	}
+	if b.Closed {
+		return errors.New("Ballot is already closed")
+	}
+	if blockHeight < b.StartHeight || blockHeight > b.EndHeight {
+		return errors.New("Ballot is not open for voting")
+	}
+	if !bytes.Equal(asset, b.EligibilityAsset) {
+		return errors.New("Vote asset is not the ballot eligibility asset")
+	}
+
+	//receivers that are not ballot options are used only as decoys and their changes are discarded
+	found := false
+	for i, publicKey := range publicKeyList {
+		if (i%2 == 0) != parity { //receiver
+			if option := b.GetOptionIndex(publicKey); option != -1 {
+				b.Tallies[option].AddEchanges(echangesAll[i])
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return errors.New("Vote doesn't include any ballot option")
+	}
+
+	return dataStorage.Ballots.Update(string(ballotId), b)
+}
+
+func (dataStorage *DataStorage) VerifyIdentityIssuer(publicKey []byte) error {
+	isIssuer, err := dataStorage.Identities.IsIssuer(publicKey)
+	if err != nil {
+		return err
+	}
+	if !isIssuer {
+		return errors.New("Public Key is not an accredited identity issuer")
+	}
+	return nil
+}
+
+func (dataStorage *DataStorage) GetOrCreateIdentity(publicKey []byte) (*identity.Identity, error) {
+
+	exists, err := dataStorage.Regs.Exists(string(publicKey))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.New("Identity requires the public key to be registered")
+	}
+
+	return dataStorage.Identities.GetOrCreateIdentity(publicKey)
+}
+
 func (dataStorage *DataStorage) SubtractUnclaimed(plainAcc *plain_account.PlainAccount, amount, blockHeight uint64) (err error) {
 
 	if err = plainAcc.AddUnclaimed(false, amount); err != nil {
@@ -407,6 +479,11 @@ func NewDataStorage(dbTx store_db_interface.StoreDBTransactionInterface) (out *D
 		conditional_payments_list.NewConditionalPaymentsCollection(dbTx),
 		assets.NewAssets(dbTx),
 		assets.NewAssetsFeeLiquidityCollection(dbTx),
+		ballots.NewBallots(dbTx),
+		identities.NewIdentities(dbTx),
+		notarizations.NewNotarizations(dbTx),
+		state_tree.NewStateTree(dbTx),
+		false,
 	}
 
 	return
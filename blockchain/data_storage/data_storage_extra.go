@@ -19,6 +19,9 @@ func (dataStorage *DataStorage) GetListWithoutCollections() (list []hash_map.Has
 		dataStorage.PlainAccs.HashMap,
 		dataStorage.PendingStakes.HashMap,
 		dataStorage.Asts.HashMap,
+		dataStorage.Ballots.HashMap,
+		dataStorage.Identities.HashMap,
+		dataStorage.Notarizations.HashMap,
 	}
 }
 
@@ -29,6 +32,9 @@ func (dataStorage *DataStorage) GetList(computeChangesSize bool) (list []hash_ma
 		dataStorage.PlainAccs.HashMap,
 		dataStorage.PendingStakes.HashMap,
 		dataStorage.Asts.HashMap,
+		dataStorage.Ballots.HashMap,
+		dataStorage.Identities.HashMap,
+		dataStorage.Notarizations.HashMap,
 	}
 
 	list = append(list, dataStorage.AccsCollection.GetAllHashmaps()...)
@@ -57,24 +63,52 @@ func (dataStorage *DataStorage) ResetChangesSize() {
 }
 
 func (dataStorage *DataStorage) Rollback() {
+	dataStorage.stateTreeWritten = false
 	list := dataStorage.GetList(false)
 	for _, it := range list {
 		it.Rollback()
 	}
 }
 
+//the state root is known before the changes are committed. The mempool uses read only transactions and doesn't need it
+func (dataStorage *DataStorage) WriteStateTree() error {
+
+	if dataStorage.stateTreeWritten || !dataStorage.DBTx.IsWritable() {
+		return nil
+	}
+
+	list := dataStorage.GetList(false)
+	for _, it := range list {
+		it.WriteStateTree(dataStorage.StateTree)
+	}
+	if err := dataStorage.StateTree.Commit(); err != nil {
+		return err
+	}
+
+	dataStorage.stateTreeWritten = true
+	return nil
+}
+
 func (dataStorage *DataStorage) CommitChanges() (err error) {
+
+	if err = dataStorage.WriteStateTree(); err != nil {
+		return
+	}
+
 	list := dataStorage.GetList(false)
 	for _, it := range list {
 		if err = it.CommitChanges(); err != nil {
 			return
 		}
 	}
+
+	dataStorage.stateTreeWritten = false
 	return
 }
 
 func (dataStorage *DataStorage) SetTx(dbTx store_db_interface.StoreDBTransactionInterface) {
 	dataStorage.DBTx = dbTx
+	dataStorage.StateTree.Tx = dbTx
 	list := dataStorage.GetList(false)
 	for _, it := range list {
 		it.SetTx(dbTx)
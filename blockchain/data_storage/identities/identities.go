@@ -0,0 +1,41 @@
+package identities
+
+import (
+	"pandora-pay/blockchain/data_storage/identities/identity"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type Identities struct {
+	*hash_map.HashMap[*identity.Identity]
+}
+
+func (this *Identities) GetOrCreateIdentity(publicKey []byte) (*identity.Identity, error) {
+	id, err := this.Get(string(publicKey))
+	if err != nil || id != nil {
+		return id, err
+	}
+	return identity.NewIdentity(publicKey, 0), nil //index will be set by update
+}
+
+func (this *Identities) IsIssuer(publicKey []byte) (bool, error) {
+	id, err := this.Get(string(publicKey))
+	if err != nil || id == nil {
+		return false, err
+	}
+	return id.Issuer, nil
+}
+
+func NewIdentities(tx store_db_interface.StoreDBTransactionInterface) (this *Identities) {
+
+	this = &Identities{
+		hash_map.CreateNewHashMap[*identity.Identity](tx, "identities", cryptography.PublicKeySize, true),
+	}
+
+	this.HashMap.CreateObject = func(key []byte, index uint64) (*identity.Identity, error) {
+		return identity.NewIdentity(key, index), nil
+	}
+
+	return
+}
@@ -0,0 +1,156 @@
+package identity
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/config/config_identities"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type Identity struct {
+	PublicKey    []byte                 `json:"-" msgpack:"-"` //hashMap key
+	Index        uint64                 `json:"-" msgpack:"-"` //hashMap index
+	Version      uint64                 `json:"version" msgpack:"version"`
+	Issuer       bool                   `json:"issuer" msgpack:"issuer"`                                 //accredited issuer
+	AccreditedBy []byte                 `json:"accreditedBy,omitempty" msgpack:"accreditedBy,omitempty"` //issuer that accredited it, empty for the genesis issuers
+	Attestations []*IdentityAttestation `json:"attestations" msgpack:"attestations"`
+}
+
+func (identity *Identity) IsDeletable() bool {
+	return !identity.Issuer && len(identity.Attestations) == 0
+}
+
+func (identity *Identity) SetKey(key []byte) {
+	identity.PublicKey = key
+}
+
+func (identity *Identity) SetIndex(value uint64) {
+	identity.Index = value
+}
+
+func (identity *Identity) GetIndex() uint64 {
+	return identity.Index
+}
+
+// returns the attestation that was not revoked and didn't expire yet
+func (identity *Identity) GetAttestation(issuer, claimHash []byte, blockHeight uint64) *IdentityAttestation {
+	for _, attestation := range identity.Attestations {
+		if attestation.IsActive(blockHeight) && bytes.Equal(attestation.Issuer, issuer) && bytes.Equal(attestation.ClaimHash, claimHash) {
+			return attestation
+		}
+	}
+	return nil
+}
+
+func (identity *Identity) GetActiveAttestations(blockHeight uint64) []*IdentityAttestation {
+	list := make([]*IdentityAttestation, 0)
+	for _, attestation := range identity.Attestations {
+		if attestation.IsActive(blockHeight) {
+			list = append(list, attestation)
+		}
+	}
+	return list
+}
+
+// the revoked and the expired attestations are removed once the identity is full
+func (identity *Identity) AddAttestation(attestation *IdentityAttestation, blockHeight uint64) error {
+	if identity.GetAttestation(attestation.Issuer, attestation.ClaimHash, blockHeight) != nil {
+		return errors.New("Attestation already exists")
+	}
+	if len(identity.Attestations) >= config_identities.IDENTITY_ATTESTATIONS_MAX {
+		identity.Attestations = identity.GetActiveAttestations(blockHeight)
+	}
+	if len(identity.Attestations) >= config_identities.IDENTITY_ATTESTATIONS_MAX {
+		return errors.New("Identity has too many attestations")
+	}
+	identity.Attestations = append(identity.Attestations, attestation)
+	return nil
+}
+
+func (identity *Identity) SetIssuer(issuer bool, accreditedBy []byte) {
+	identity.Issuer = issuer
+	if issuer {
+		identity.AccreditedBy = accreditedBy
+	} else {
+		identity.AccreditedBy = nil
+	}
+}
+
+func (identity *Identity) Validate() error {
+	if identity.Version != 0 {
+		return errors.New("Identity Version is invalid")
+	}
+	if len(identity.AccreditedBy) != 0 && (!identity.Issuer || len(identity.AccreditedBy) != cryptography.PublicKeySize) {
+		return errors.New("Identity AccreditedBy is invalid")
+	}
+	if len(identity.Attestations) > config_identities.IDENTITY_ATTESTATIONS_MAX {
+		return errors.New("Identity has too many attestations")
+	}
+	for _, attestation := range identity.Attestations {
+		if err := attestation.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (identity *Identity) Serialize(w *advanced_buffers.BufferWriter) {
+	w.WriteUvarint(identity.Version)
+	w.WriteBool(identity.Issuer)
+	if identity.Issuer {
+		w.WriteBool(len(identity.AccreditedBy) > 0)
+		if len(identity.AccreditedBy) > 0 {
+			w.Write(identity.AccreditedBy)
+		}
+	}
+	w.WriteUvarint(uint64(len(identity.Attestations)))
+	for _, attestation := range identity.Attestations {
+		attestation.Serialize(w)
+	}
+}
+
+func (identity *Identity) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if identity.Version, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if identity.Issuer, err = r.ReadBool(); err != nil {
+		return
+	}
+	if identity.Issuer {
+		var accredited bool
+		if accredited, err = r.ReadBool(); err != nil {
+			return
+		}
+		if accredited {
+			if identity.AccreditedBy, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+				return
+			}
+		}
+	}
+
+	var n uint64
+	if n, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if n > uint64(config_identities.IDENTITY_ATTESTATIONS_MAX) {
+		return errors.New("Identity has too many attestations")
+	}
+
+	identity.Attestations = make([]*IdentityAttestation, n)
+	for i := range identity.Attestations {
+		identity.Attestations[i] = &IdentityAttestation{}
+		if err = identity.Attestations[i].Deserialize(r); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func NewIdentity(publicKey []byte, index uint64) *Identity {
+	return &Identity{
+		PublicKey:    publicKey,
+		Index:        index,
+		Attestations: make([]*IdentityAttestation, 0),
+	}
+}
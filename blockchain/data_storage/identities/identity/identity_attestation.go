@@ -0,0 +1,80 @@
+package identity
+
+import (
+	"errors"
+	"pandora-pay/config/config_identities"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type IdentityAttestation struct {
+	Issuer        []byte `json:"issuer" msgpack:"issuer"`
+	ClaimHash     []byte `json:"claimHash" msgpack:"claimHash"` //hashed citizen ID
+	Jurisdiction  string `json:"jurisdiction" msgpack:"jurisdiction"`
+	Expiry        uint64 `json:"expiry" msgpack:"expiry"` //block height
+	IssuedHeight  uint64 `json:"issuedHeight" msgpack:"issuedHeight"`
+	Revoked       bool   `json:"revoked" msgpack:"revoked"`
+	RevokedHeight uint64 `json:"revokedHeight,omitempty" msgpack:"revokedHeight,omitempty"`
+}
+
+func (attestation *IdentityAttestation) IsActive(blockHeight uint64) bool {
+	return !attestation.Revoked && blockHeight < attestation.Expiry
+}
+
+func (attestation *IdentityAttestation) Validate() error {
+	if len(attestation.Issuer) != cryptography.PublicKeySize {
+		return errors.New("Attestation issuer is invalid")
+	}
+	if len(attestation.ClaimHash) != cryptography.HashSize {
+		return errors.New("Attestation claim hash is invalid")
+	}
+	if len(attestation.Jurisdiction) == 0 || len(attestation.Jurisdiction) > config_identities.IDENTITY_JURISDICTION_MAX_LENGTH {
+		return errors.New("Attestation jurisdiction is invalid")
+	}
+	if attestation.Expiry <= attestation.IssuedHeight {
+		return errors.New("Attestation expiry is invalid")
+	}
+	if !attestation.Revoked && attestation.RevokedHeight != 0 {
+		return errors.New("Attestation revoked height should be zero")
+	}
+	return nil
+}
+
+func (attestation *IdentityAttestation) Serialize(w *advanced_buffers.BufferWriter) {
+	w.Write(attestation.Issuer)
+	w.Write(attestation.ClaimHash)
+	w.WriteString(attestation.Jurisdiction)
+	w.WriteUvarint(attestation.Expiry)
+	w.WriteUvarint(attestation.IssuedHeight)
+	w.WriteBool(attestation.Revoked)
+	if attestation.Revoked {
+		w.WriteUvarint(attestation.RevokedHeight)
+	}
+}
+
+func (attestation *IdentityAttestation) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if attestation.Issuer, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if attestation.ClaimHash, err = r.ReadBytes(cryptography.HashSize); err != nil {
+		return
+	}
+	if attestation.Jurisdiction, err = r.ReadString(uint64(config_identities.IDENTITY_JURISDICTION_MAX_LENGTH)); err != nil {
+		return
+	}
+	if attestation.Expiry, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if attestation.IssuedHeight, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if attestation.Revoked, err = r.ReadBool(); err != nil {
+		return
+	}
+	if attestation.Revoked {
+		if attestation.RevokedHeight, err = r.ReadUvarint(); err != nil {
+			return
+		}
+	}
+	return
+}
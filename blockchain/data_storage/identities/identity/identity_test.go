@@ -0,0 +1,77 @@
+package identity
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/config/config_identities"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"testing"
+)
+
+func newTestAttestation(issuer []byte, issuedHeight, expiry uint64) *IdentityAttestation {
+	return &IdentityAttestation{
+		Issuer:       issuer,
+		ClaimHash:    cryptography.RandomHash(),
+		Jurisdiction: "RO",
+		Expiry:       expiry,
+		IssuedHeight: issuedHeight,
+	}
+}
+
+func TestIdentity_AddAttestation(t *testing.T) {
+
+	id := NewIdentity(helpers.RandomBytes(cryptography.PublicKeySize), 0)
+	issuer := helpers.RandomBytes(cryptography.PublicKeySize)
+
+	attestation := newTestAttestation(issuer, 10, 100)
+	assert.Nil(t, id.AddAttestation(attestation, 10))
+	assert.Equal(t, attestation, id.GetAttestation(issuer, attestation.ClaimHash, 10))
+	assert.NotNil(t, id.AddAttestation(&IdentityAttestation{issuer, attestation.ClaimHash, "RO", 200, 20, false, 0}, 20))
+
+	//the expired attestations are not returned and they can be issued again
+	assert.Nil(t, id.GetAttestation(issuer, attestation.ClaimHash, 100))
+	assert.Nil(t, id.AddAttestation(&IdentityAttestation{issuer, attestation.ClaimHash, "RO", 200, 100, false, 0}, 100))
+	assert.Equal(t, 1, len(id.GetActiveAttestations(100)))
+
+	//the inactive attestations are removed once the identity is full
+	for len(id.Attestations) < config_identities.IDENTITY_ATTESTATIONS_MAX {
+		assert.Nil(t, id.AddAttestation(newTestAttestation(issuer, 100, 200), 100))
+	}
+	assert.Nil(t, id.AddAttestation(newTestAttestation(issuer, 100, 200), 100))
+	assert.Equal(t, config_identities.IDENTITY_ATTESTATIONS_MAX, len(id.Attestations))
+	assert.NotNil(t, id.AddAttestation(newTestAttestation(issuer, 100, 200), 100))
+
+	id.Attestations[5].Revoked = true
+	id.Attestations[5].RevokedHeight = 150
+	assert.Nil(t, id.AddAttestation(newTestAttestation(issuer, 150, 300), 150))
+	assert.Equal(t, config_identities.IDENTITY_ATTESTATIONS_MAX, len(id.Attestations))
+	assert.Equal(t, config_identities.IDENTITY_ATTESTATIONS_MAX, len(id.GetActiveAttestations(150)))
+
+	assert.Nil(t, id.AddAttestation(newTestAttestation(issuer, 200, 300), 200))
+	assert.Equal(t, 2, len(id.Attestations))
+
+	assert.Nil(t, id.Validate())
+}
+
+func TestIdentity_Serialize(t *testing.T) {
+
+	id := NewIdentity(helpers.RandomBytes(cryptography.PublicKeySize), 0)
+	id.SetIssuer(true, helpers.RandomBytes(cryptography.PublicKeySize))
+	assert.Nil(t, id.AddAttestation(newTestAttestation(helpers.RandomBytes(cryptography.PublicKeySize), 10, 100), 10))
+	id.Attestations[0].Revoked = true
+	id.Attestations[0].RevokedHeight = 20
+	assert.Nil(t, id.Validate())
+
+	id2 := &Identity{}
+	assert.Nil(t, id2.Deserialize(advanced_buffers.NewBufferReader(helpers.SerializeToBytes(id))))
+	assert.Equal(t, id.Issuer, id2.Issuer)
+	assert.Equal(t, id.AccreditedBy, id2.AccreditedBy)
+	assert.Equal(t, id.Attestations, id2.Attestations)
+
+	//only the issuers keep the accreditation
+	id.SetIssuer(false, id.AccreditedBy)
+	assert.Nil(t, id.AccreditedBy)
+	id.AccreditedBy = helpers.RandomBytes(cryptography.PublicKeySize)
+	assert.NotNil(t, id.Validate())
+}
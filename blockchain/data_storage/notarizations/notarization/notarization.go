@@ -0,0 +1,108 @@
+package notarization
+
+import (
+	"errors"
+	"pandora-pay/config/config_notarizations"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type Notarization struct {
+	DocumentHash    []byte `json:"-" msgpack:"-"` //hashMap key
+	Index           uint64 `json:"-" msgpack:"-"` //hashMap index
+	Version         uint64 `json:"version" msgpack:"version"`
+	MimeType        string `json:"mimeType" msgpack:"mimeType"`
+	Notary          []byte `json:"notary" msgpack:"notary"`
+	Issuer          []byte `json:"issuer,omitempty" msgpack:"issuer,omitempty"`
+	IssuerSignature []byte `json:"issuerSignature,omitempty" msgpack:"issuerSignature,omitempty"`
+	TxHash          []byte `json:"txHash" msgpack:"txHash"`
+	BlockHeight     uint64 `json:"blockHeight" msgpack:"blockHeight"`
+}
+
+func (notarization *Notarization) IsDeletable() bool {
+	return false
+}
+
+func (notarization *Notarization) SetKey(key []byte) {
+	notarization.DocumentHash = key
+}
+
+func (notarization *Notarization) SetIndex(value uint64) {
+	notarization.Index = value
+}
+
+func (notarization *Notarization) GetIndex() uint64 {
+	return notarization.Index
+}
+
+func (notarization *Notarization) Validate() error {
+	if notarization.Version != 0 {
+		return errors.New("Notarization Version is invalid")
+	}
+	if len(notarization.MimeType) == 0 || len(notarization.MimeType) > config_notarizations.NOTARIZATION_MIME_TYPE_MAX_LENGTH {
+		return errors.New("Notarization mime type is invalid")
+	}
+	if len(notarization.Notary) != cryptography.PublicKeySize {
+		return errors.New("Notarization notary is invalid")
+	}
+	if len(notarization.Issuer) > 0 && (len(notarization.Issuer) != cryptography.PublicKeySize || len(notarization.IssuerSignature) != cryptography.SignatureSize) {
+		return errors.New("Notarization issuer is invalid")
+	}
+	if len(notarization.TxHash) != cryptography.HashSize {
+		return errors.New("Notarization tx hash is invalid")
+	}
+	return nil
+}
+
+func (notarization *Notarization) Serialize(w *advanced_buffers.BufferWriter) {
+	w.WriteUvarint(notarization.Version)
+	w.WriteString(notarization.MimeType)
+	w.Write(notarization.Notary)
+	w.WriteBool(len(notarization.Issuer) > 0)
+	if len(notarization.Issuer) > 0 {
+		w.Write(notarization.Issuer)
+		w.Write(notarization.IssuerSignature)
+	}
+	w.Write(notarization.TxHash)
+	w.WriteUvarint(notarization.BlockHeight)
+}
+
+func (notarization *Notarization) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if notarization.Version, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if notarization.MimeType, err = r.ReadString(uint64(config_notarizations.NOTARIZATION_MIME_TYPE_MAX_LENGTH)); err != nil {
+		return
+	}
+	if notarization.Notary, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+
+	var hasIssuer bool
+	if hasIssuer, err = r.ReadBool(); err != nil {
+		return
+	}
+	if hasIssuer {
+		if notarization.Issuer, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+		if notarization.IssuerSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+			return
+		}
+	}
+
+	if notarization.TxHash, err = r.ReadBytes(cryptography.HashSize); err != nil {
+		return
+	}
+	if notarization.BlockHeight, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	return
+}
+
+func NewNotarization(documentHash []byte, index uint64) *Notarization {
+	return &Notarization{
+		DocumentHash: documentHash,
+		Index:        index,
+	}
+}
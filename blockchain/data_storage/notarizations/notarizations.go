@@ -0,0 +1,25 @@
+package notarizations
+
+import (
+	"pandora-pay/blockchain/data_storage/notarizations/notarization"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/hash_map"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type Notarizations struct {
+	*hash_map.HashMap[*notarization.Notarization]
+}
+
+func NewNotarizations(tx store_db_interface.StoreDBTransactionInterface) (this *Notarizations) {
+
+	this = &Notarizations{
+		hash_map.CreateNewHashMap[*notarization.Notarization](tx, "notarizations", cryptography.HashSize, true),
+	}
+
+	this.HashMap.CreateObject = func(key []byte, index uint64) (*notarization.Notarization, error) {
+		return notarization.NewNotarization(key, index), nil
+	}
+
+	return
+}
@@ -30,6 +30,7 @@ type GenesisDataType struct {
 	Timestamp  uint64                    `json:"timestamp" msgpack:"timestamp"`
 	Target     []byte                    `json:"target" msgpack:"target"` //32 byte
 	AirDrops   []*GenesisDataAirDropType `json:"airDrops" msgpack:"airDrops"`
+	Issuers    []string                  `json:"issuers,omitempty" msgpack:"issuers,omitempty"` //accredited identity issuers addresses
 }
 
 var genesisMainet = GenesisDataType{
@@ -77,7 +78,7 @@ func CreateNewGenesisBlock() (*block.Block, error) {
 
 	var blk = block.Block{
 		BlockHeader: &block.BlockHeader{
-			Version: 0,
+			Version: block.GetBlockVersion(0),
 			Height:  0,
 		},
 		MerkleHash:     cryptography.SHA3([]byte{}),
@@ -86,6 +87,10 @@ func CreateNewGenesisBlock() (*block.Block, error) {
 		PrevKernelHash: GenesisData.KernelHash,
 	}
 
+	if blk.Version == block.BLOCK_VERSION_STATE_ROOT {
+		blk.StateRoot = make([]byte, cryptography.HashSize)
+	}
+
 	return &blk, nil
 }
 
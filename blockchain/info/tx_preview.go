@@ -56,6 +56,54 @@ func CreateTxPreviewFromTx(tx *transaction.Transaction) (*TxPreview, error) {
 				txBaseExtra.PayloadIndex,
 				txBaseExtra.Resolution,
 			}
+		case transaction_simple.SCRIPT_BALLOT_CLOSE:
+
+			txBaseExtra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraBallotClose)
+
+			previewBase.Extra = &TxPreviewSimpleExtraBallotClose{
+				txBaseExtra.BallotId,
+				txBaseExtra.Results,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ISSUE:
+
+			txBaseExtra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraIdentityAttestationIssue)
+
+			previewBase.Extra = &TxPreviewSimpleExtraIdentityAttestation{
+				txBaseExtra.PublicKey,
+				txBaseExtra.ClaimHash,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_REVOKE:
+
+			txBaseExtra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRevoke)
+
+			previewBase.Extra = &TxPreviewSimpleExtraIdentityAttestation{
+				txBaseExtra.PublicKey,
+				txBaseExtra.ClaimHash,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ROTATE:
+
+			txBaseExtra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRotate)
+
+			previewBase.Extra = &TxPreviewSimpleExtraIdentityAttestation{
+				txBaseExtra.PublicKey,
+				txBaseExtra.NewClaimHash,
+			}
+		case transaction_simple.SCRIPT_NOTARIZE_DOCUMENT:
+
+			txBaseExtra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraNotarizeDocument)
+
+			previewBase.Extra = &TxPreviewSimpleExtraNotarizeDocument{
+				txBaseExtra.DocumentHash,
+				txBaseExtra.MimeType,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ISSUER_UPDATE:
+
+			txBaseExtra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraIdentityIssuerUpdate)
+
+			previewBase.Extra = &TxPreviewSimpleExtraIdentityIssuerUpdate{
+				txBaseExtra.PublicKey,
+				txBaseExtra.Issuer,
+			}
 		}
 
 		base = previewBase
@@ -87,6 +135,12 @@ func CreateTxPreviewFromTx(tx *transaction.Transaction) (*TxPreview, error) {
 			case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
 				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment)
 				payloadExtra = &TxPreviewZetherPayloadExtraPayToScript{txPayloadExtra.Deadline, txPayloadExtra.DefaultResolution, txPayloadExtra.MultisigThreshold}
+			case transaction_zether_payload_script.SCRIPT_BALLOT_CREATE:
+				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotCreate)
+				payloadExtra = &TxPreviewZetherPayloadExtraBallotCreate{txPayloadExtra.Title, txPayloadExtra.Options, txPayloadExtra.EligibilityAsset, txPayloadExtra.StartHeight, txPayloadExtra.EndHeight, txPayloadExtra.Creator}
+			case transaction_zether_payload_script.SCRIPT_BALLOT_VOTE:
+				txPayloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotVote)
+				payloadExtra = &TxPreviewZetherPayloadExtraBallotVote{txPayloadExtra.BallotId}
 			}
 
 			payloads[i] = &TxPreviewZetherPayload{
@@ -11,6 +11,26 @@ type TxPreviewSimpleExtraResolutionConditionalPayment struct {
 	Resolution   bool   `json:"resolution" msgpack:"resolution"`
 }
 
+type TxPreviewSimpleExtraBallotClose struct {
+	BallotId []byte   `json:"ballotId" msgpack:"ballotId"`
+	Results  []uint64 `json:"results" msgpack:"results"`
+}
+
+type TxPreviewSimpleExtraIdentityAttestation struct {
+	PublicKey []byte `json:"publicKey" msgpack:"publicKey"`
+	ClaimHash []byte `json:"claimHash" msgpack:"claimHash"`
+}
+
+type TxPreviewSimpleExtraIdentityIssuerUpdate struct {
+	PublicKey []byte `json:"publicKey" msgpack:"publicKey"`
+	Issuer    bool   `json:"issuer" msgpack:"issuer"`
+}
+
+type TxPreviewSimpleExtraNotarizeDocument struct {
+	DocumentHash []byte `json:"documentHash" msgpack:"documentHash"`
+	MimeType     string `json:"mimeType" msgpack:"mimeType"`
+}
+
 type TxPreviewSimple struct {
 	TxScript    transaction_simple.ScriptType           `json:"txScript" msgpack:"txScript"`
 	DataVersion transaction_data.TransactionDataVersion `json:"dataVersion" msgpack:"dataVersion"`
@@ -21,6 +21,19 @@ type TxPreviewZetherPayloadExtraPayToScript struct {
 	Threshold         byte   `json:"threshold" msgpack:"threshold"`
 }
 
+type TxPreviewZetherPayloadExtraBallotCreate struct {
+	Title            string   `json:"title" msgpack:"title"`
+	Options          []string `json:"options" msgpack:"options"`
+	EligibilityAsset []byte   `json:"eligibilityAsset" msgpack:"eligibilityAsset"`
+	StartHeight      uint64   `json:"startHeight" msgpack:"startHeight"`
+	EndHeight        uint64   `json:"endHeight" msgpack:"endHeight"`
+	Creator          []byte   `json:"creator" msgpack:"creator"`
+}
+
+type TxPreviewZetherPayloadExtraBallotVote struct {
+	BallotId []byte `json:"ballotId" msgpack:"ballotId"`
+}
+
 type TxPreviewZetherPayload struct {
 	PayloadScript transaction_zether_payload_script.PayloadScriptType `json:"payloadScript" msgpack:"payloadScript"`
 	Asset         []byte                                              `json:"asset" msgpack:"asset"`
@@ -8,6 +8,7 @@ import (
 	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/config"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers/advanced_buffers"
 )
@@ -16,11 +17,20 @@ type Transaction struct {
 	transaction_base_interface.TransactionBaseInterface
 	Version    transaction_type.TransactionVersion
 	SpaceExtra uint64
-	Bloom      *TransactionBloom
+	//the tx can't be included in a block greater than ExpiryHeight. Zero means that the tx never expires
+	ExpiryHeight uint64
+	Bloom        *TransactionBloom
 }
 
 func (tx *Transaction) IncludeTransaction(blockHeight uint64, dataStorage *data_storage.DataStorage) error {
 
+	if tx.ExpiryHeight > 0 && blockHeight < config.NETWORK_SELECTED_FORKS.TxExpiry {
+		return errors.New("Transaction expiry is not activated yet")
+	}
+	if tx.IsExpired(blockHeight) {
+		return fmt.Errorf("Transaction expired at height %d", tx.ExpiryHeight)
+	}
+
 	dataStorage.ResetChangesSize()
 
 	if err := tx.TransactionBaseInterface.IncludeTransaction(blockHeight, tx.Bloom.Hash, dataStorage); err != nil {
@@ -35,6 +45,10 @@ func (tx *Transaction) IncludeTransaction(blockHeight uint64, dataStorage *data_
 	return nil
 }
 
+func (tx *Transaction) IsExpired(blockHeight uint64) bool {
+	return tx.ExpiryHeight > 0 && blockHeight > tx.ExpiryHeight
+}
+
 func (tx *Transaction) GetAllFee() (uint64, error) {
 	return tx.ComputeFee()
 }
@@ -61,8 +75,15 @@ func (tx *Transaction) GetHashSigningManually() []byte {
 }
 
 func (tx *Transaction) SerializeAdvanced(w *advanced_buffers.BufferWriter, inclSignature bool) {
-	w.WriteUvarint(uint64(tx.Version))
+	if tx.ExpiryHeight > 0 {
+		w.WriteUvarint(uint64(tx.Version) | transaction_type.TX_VERSION_FLAG_EXPIRY)
+	} else {
+		w.WriteUvarint(uint64(tx.Version))
+	}
 	w.WriteUvarint(tx.SpaceExtra)
+	if tx.ExpiryHeight > 0 {
+		w.WriteUvarint(tx.ExpiryHeight)
+	}
 	tx.TransactionBaseInterface.SerializeAdvanced(w, inclSignature)
 }
 
@@ -100,7 +121,8 @@ func (tx *Transaction) Deserialize(r *advanced_buffers.BufferReader) (err error)
 	if n, err = r.ReadUvarint(); err != nil {
 		return
 	}
-	tx.Version = transaction_type.TransactionVersion(n)
+	hasExpiry := n&transaction_type.TX_VERSION_FLAG_EXPIRY != 0
+	tx.Version = transaction_type.TransactionVersion(n &^ transaction_type.TX_VERSION_FLAG_EXPIRY)
 
 	switch tx.Version {
 	case transaction_type.TX_SIMPLE:
@@ -115,6 +137,16 @@ func (tx *Transaction) Deserialize(r *advanced_buffers.BufferReader) (err error)
 		return
 	}
 
+	tx.ExpiryHeight = 0
+	if hasExpiry {
+		if tx.ExpiryHeight, err = r.ReadUvarint(); err != nil {
+			return
+		}
+		if tx.ExpiryHeight == 0 {
+			return errors.New("ExpiryHeight can not be zero")
+		}
+	}
+
 	if err = tx.TransactionBaseInterface.Deserialize(r); err != nil {
 		return
 	}
@@ -33,10 +33,11 @@ type json_TransactionDataRegistration struct {
 }
 
 type Json_Transaction struct {
-	Version    transaction_type.TransactionVersion `json:"version" msgpack:"version"`
-	Size       uint64                              `json:"size" msgpack:"size"`
-	SpaceExtra uint64                              `json:"spaceExtra" msgpack:"spaceExtra"`
-	Hash       []byte                              `json:"hash" msgpack:"hash"`
+	Version      transaction_type.TransactionVersion `json:"version" msgpack:"version"`
+	Size         uint64                              `json:"size" msgpack:"size"`
+	SpaceExtra   uint64                              `json:"spaceExtra" msgpack:"spaceExtra"`
+	ExpiryHeight uint64                              `json:"expiryHeight,omitempty" msgpack:"expiryHeight,omitempty"`
+	Hash         []byte                              `json:"hash" msgpack:"hash"`
 }
 
 type json_TransactionSimple struct {
@@ -73,6 +74,44 @@ type json_Only_TransactionSimpleExtraResolutionConditionalPayment struct {
 	Signatures         [][]byte `json:"signatures"`
 }
 
+type json_Only_TransactionSimpleExtraBallotClose struct {
+	BallotId           []byte   `json:"ballotId"`
+	Results            []uint64 `json:"results"`
+	OptionsPrivateKeys [][]byte `json:"optionsPrivateKeys"`
+}
+
+type json_Only_TransactionSimpleExtraIdentityAttestationIssue struct {
+	PublicKey    []byte `json:"publicKey"`
+	ClaimHash    []byte `json:"claimHash"`
+	Jurisdiction string `json:"jurisdiction"`
+	Expiry       uint64 `json:"expiry"`
+}
+
+type json_Only_TransactionSimpleExtraIdentityAttestationRevoke struct {
+	PublicKey []byte `json:"publicKey"`
+	ClaimHash []byte `json:"claimHash"`
+}
+
+type json_Only_TransactionSimpleExtraIdentityAttestationRotate struct {
+	PublicKey    []byte `json:"publicKey"`
+	ClaimHash    []byte `json:"claimHash"`
+	NewClaimHash []byte `json:"newClaimHash"`
+	Jurisdiction string `json:"jurisdiction"`
+	Expiry       uint64 `json:"expiry"`
+}
+
+type json_Only_TransactionSimpleExtraIdentityIssuerUpdate struct {
+	PublicKey []byte `json:"publicKey"`
+	Issuer    bool   `json:"issuer"`
+}
+
+type json_Only_TransactionSimpleExtraNotarizeDocument struct {
+	DocumentHash    []byte `json:"documentHash"`
+	MimeType        string `json:"mimeType"`
+	Issuer          []byte `json:"issuer,omitempty"`
+	IssuerSignature []byte `json:"issuerSignature,omitempty"`
+}
+
 type json_Only_TransactionZether struct {
 	ChainHeight     uint64                          `json:"chainHeight"  msgpack:"chainHeight"`
 	ChainKernelHash []byte                          `json:"chainKernelHash"  msgpack:"chainKernelHash"`
@@ -115,6 +154,21 @@ type json_Only_TransactionZetherPayloadExtraConditionalPayment struct {
 	MultisigPublicKeys [][]byte `json:"multisigPublicKeys" msgpack:"multisigPublicKeys"`
 }
 
+type json_Only_TransactionZetherPayloadExtraBallotCreate struct {
+	Title                         string   `json:"title" msgpack:"title"`
+	Options                       []string `json:"options" msgpack:"options"`
+	OptionsPublicKeys             [][]byte `json:"optionsPublicKeys" msgpack:"optionsPublicKeys"`
+	OptionsRegistrationSignatures [][]byte `json:"optionsRegistrationSignatures" msgpack:"optionsRegistrationSignatures"`
+	EligibilityAsset              []byte   `json:"eligibilityAsset" msgpack:"eligibilityAsset"`
+	StartHeight                   uint64   `json:"startHeight" msgpack:"startHeight"`
+	EndHeight                     uint64   `json:"endHeight" msgpack:"endHeight"`
+	Creator                       []byte   `json:"creator" msgpack:"creator"`
+}
+
+type json_Only_TransactionZetherPayloadExtraBallotVote struct {
+	BallotId []byte `json:"ballotId" msgpack:"ballotId"`
+}
+
 type json_Only_TransactionZetherStatement struct {
 	RingSize      int      `json:"ringSize"  msgpack:"ringSize"`
 	CLn           [][]byte `json:"cLn"  msgpack:"cLn"`
@@ -153,6 +207,7 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 		tx.Version,
 		tx.Bloom.Size,
 		tx.SpaceExtra,
+		tx.ExpiryHeight,
 		tx.Bloom.Hash,
 	}
 
@@ -198,6 +253,50 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 				extra.Signatures,
 			}
 		case transaction_simple.SCRIPT_NOTHING:
+		case transaction_simple.SCRIPT_BALLOT_CLOSE:
+			extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraBallotClose)
+			simpleJson.Extra = json_Only_TransactionSimpleExtraBallotClose{
+				extra.BallotId,
+				extra.Results,
+				extra.OptionsPrivateKeys,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ISSUE:
+			extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraIdentityAttestationIssue)
+			simpleJson.Extra = json_Only_TransactionSimpleExtraIdentityAttestationIssue{
+				extra.PublicKey,
+				extra.ClaimHash,
+				extra.Jurisdiction,
+				extra.Expiry,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_REVOKE:
+			extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRevoke)
+			simpleJson.Extra = json_Only_TransactionSimpleExtraIdentityAttestationRevoke{
+				extra.PublicKey,
+				extra.ClaimHash,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ROTATE:
+			extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRotate)
+			simpleJson.Extra = json_Only_TransactionSimpleExtraIdentityAttestationRotate{
+				extra.PublicKey,
+				extra.ClaimHash,
+				extra.NewClaimHash,
+				extra.Jurisdiction,
+				extra.Expiry,
+			}
+		case transaction_simple.SCRIPT_NOTARIZE_DOCUMENT:
+			extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraNotarizeDocument)
+			simpleJson.Extra = json_Only_TransactionSimpleExtraNotarizeDocument{
+				extra.DocumentHash,
+				extra.MimeType,
+				extra.Issuer,
+				extra.IssuerSignature,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ISSUER_UPDATE:
+			extra := base.Extra.(*transaction_simple_extra.TransactionSimpleExtraIdentityIssuerUpdate)
+			simpleJson.Extra = json_Only_TransactionSimpleExtraIdentityIssuerUpdate{
+				extra.PublicKey,
+				extra.Issuer,
+			}
 		default:
 			return nil, errors.New("Invalid simple.TxScript")
 		}
@@ -283,6 +382,23 @@ func marshalJSON(tx *Transaction, marshal func(any) ([]byte, error)) ([]byte, er
 					payloadExtra.MultisigThreshold,
 					payloadExtra.MultisigPublicKeys,
 				}
+			case transaction_zether_payload_script.SCRIPT_BALLOT_CREATE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotCreate)
+				extra = &json_Only_TransactionZetherPayloadExtraBallotCreate{
+					payloadExtra.Title,
+					payloadExtra.Options,
+					payloadExtra.OptionsPublicKeys,
+					payloadExtra.OptionsRegistrationSignatures,
+					payloadExtra.EligibilityAsset,
+					payloadExtra.StartHeight,
+					payloadExtra.EndHeight,
+					payloadExtra.Creator,
+				}
+			case transaction_zether_payload_script.SCRIPT_BALLOT_VOTE:
+				payloadExtra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotVote)
+				extra = &json_Only_TransactionZetherPayloadExtraBallotVote{
+					payloadExtra.BallotId,
+				}
 			default:
 				return nil, errors.New("Invalid zether.TxScript")
 			}
@@ -350,6 +466,7 @@ func (tx *Transaction) UnmarshalJSON(data []byte) (err error) {
 
 	tx.Version = txOnlyJson.Version
 	tx.SpaceExtra = txOnlyJson.SpaceExtra
+	tx.ExpiryHeight = txOnlyJson.ExpiryHeight
 
 	switch tx.Version {
 	case transaction_type.TX_SIMPLE:
@@ -415,6 +532,74 @@ func (tx *Transaction) UnmarshalJSON(data []byte) (err error) {
 				extraJson.MultisigPublicKeys,
 				extraJson.Signatures,
 			}
+		case transaction_simple.SCRIPT_BALLOT_CLOSE:
+			extraJson := &json_Only_TransactionSimpleExtraBallotClose{}
+			if err = json.Unmarshal(data, extraJson); err != nil {
+				return
+			}
+
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraBallotClose{nil,
+				extraJson.BallotId,
+				extraJson.Results,
+				extraJson.OptionsPrivateKeys,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ISSUE:
+			extraJson := &json_Only_TransactionSimpleExtraIdentityAttestationIssue{}
+			if err = json.Unmarshal(data, extraJson); err != nil {
+				return
+			}
+
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationIssue{nil,
+				extraJson.PublicKey,
+				extraJson.ClaimHash,
+				extraJson.Jurisdiction,
+				extraJson.Expiry,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_REVOKE:
+			extraJson := &json_Only_TransactionSimpleExtraIdentityAttestationRevoke{}
+			if err = json.Unmarshal(data, extraJson); err != nil {
+				return
+			}
+
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRevoke{nil,
+				extraJson.PublicKey,
+				extraJson.ClaimHash,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ROTATE:
+			extraJson := &json_Only_TransactionSimpleExtraIdentityAttestationRotate{}
+			if err = json.Unmarshal(data, extraJson); err != nil {
+				return
+			}
+
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRotate{nil,
+				extraJson.PublicKey,
+				extraJson.ClaimHash,
+				extraJson.NewClaimHash,
+				extraJson.Jurisdiction,
+				extraJson.Expiry,
+			}
+		case transaction_simple.SCRIPT_NOTARIZE_DOCUMENT:
+			extraJson := &json_Only_TransactionSimpleExtraNotarizeDocument{}
+			if err = json.Unmarshal(data, extraJson); err != nil {
+				return
+			}
+
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraNotarizeDocument{nil,
+				extraJson.DocumentHash,
+				extraJson.MimeType,
+				extraJson.Issuer,
+				extraJson.IssuerSignature,
+			}
+		case transaction_simple.SCRIPT_IDENTITY_ISSUER_UPDATE:
+			extraJson := &json_Only_TransactionSimpleExtraIdentityIssuerUpdate{}
+			if err = json.Unmarshal(data, extraJson); err != nil {
+				return
+			}
+
+			base.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityIssuerUpdate{nil,
+				extraJson.PublicKey,
+				extraJson.Issuer,
+			}
 		default:
 			return errors.New("Invalid json Simple TxScript")
 		}
@@ -573,6 +758,31 @@ func (tx *Transaction) UnmarshalJSON(data []byte) (err error) {
 					extraJson.MultisigThreshold,
 					extraJson.MultisigPublicKeys,
 				}
+			case transaction_zether_payload_script.SCRIPT_BALLOT_CREATE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraBallotCreate{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotCreate{
+					nil,
+					extraJson.Title,
+					extraJson.Options,
+					extraJson.OptionsPublicKeys,
+					extraJson.OptionsRegistrationSignatures,
+					extraJson.EligibilityAsset,
+					extraJson.StartHeight,
+					extraJson.EndHeight,
+					extraJson.Creator,
+				}
+			case transaction_zether_payload_script.SCRIPT_BALLOT_VOTE:
+				extraJson := &json_Only_TransactionZetherPayloadExtraBallotVote{}
+				if err = json.Unmarshal(data, extraJson); err != nil {
+					return err
+				}
+				payloads[i].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotVote{
+					nil,
+					extraJson.BallotId,
+				}
 			default:
 				return errors.New("Invalid Zether TxScript")
 			}
@@ -51,7 +51,7 @@ func (tx *TransactionSimple) IncludeTransaction(blockHeight uint64, txHash []byt
 	}
 
 	if tx.Extra != nil {
-		if err = tx.Extra.IncludeTransactionVin0(blockHeight, plainAcc, dataStorage); err != nil {
+		if err = tx.Extra.IncludeTransactionVin0(blockHeight, txHash, plainAcc, dataStorage); err != nil {
 			return
 		}
 	}
@@ -88,6 +88,12 @@ func (tx *TransactionSimple) VerifySignatureManually(hashForSignature []byte) bo
 			return false
 		}
 	}
+	if tx.TxScript == SCRIPT_NOTARIZE_DOCUMENT {
+		extra := tx.Extra.(*transaction_simple_extra.TransactionSimpleExtraNotarizeDocument)
+		if !extra.VerifySignature() {
+			return false
+		}
+	}
 
 	return true
 }
@@ -101,7 +107,7 @@ func (tx *TransactionSimple) Validate() (err error) {
 	}
 
 	switch tx.TxScript {
-	case SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY, SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT, SCRIPT_NOTHING:
+	case SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY, SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT, SCRIPT_NOTHING, SCRIPT_BALLOT_CLOSE, SCRIPT_IDENTITY_ATTESTATION_ISSUE, SCRIPT_IDENTITY_ATTESTATION_REVOKE, SCRIPT_IDENTITY_ATTESTATION_ROTATE, SCRIPT_NOTARIZE_DOCUMENT, SCRIPT_IDENTITY_ISSUER_UPDATE:
 		if tx.Extra == nil {
 			return errors.New("extra is not assigned")
 		}
@@ -154,6 +160,18 @@ func (tx *TransactionSimple) Deserialize(r *advanced_buffers.BufferReader) (err
 		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraResolutionConditionalPayment{}
 	case SCRIPT_NOTHING:
 		TX.EXTRA = &transaction_simple_extra.TransactionSimpleNothing{}
+	case SCRIPT_BALLOT_CLOSE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraBallotClose{}
+	case SCRIPT_IDENTITY_ATTESTATION_ISSUE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationIssue{}
+	case SCRIPT_IDENTITY_ATTESTATION_REVOKE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRevoke{}
+	case SCRIPT_IDENTITY_ATTESTATION_ROTATE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRotate{}
+	case SCRIPT_NOTARIZE_DOCUMENT:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraNotarizeDocument{}
+	case SCRIPT_IDENTITY_ISSUER_UPDATE:
+		tx.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityIssuerUpdate{}
 	default:
 		return errors.New("INVALID SCRIPT TYPE")
 	}
@@ -196,7 +214,7 @@ func (tx *TransactionSimple) Deserialize(r *advanced_buffers.BufferReader) (err
 
 func (tx *TransactionSimple) HasVin() bool {
 	switch tx.TxScript {
-	case SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY:
+	case SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY, SCRIPT_BALLOT_CLOSE, SCRIPT_IDENTITY_ATTESTATION_ISSUE, SCRIPT_IDENTITY_ATTESTATION_REVOKE, SCRIPT_IDENTITY_ATTESTATION_ROTATE, SCRIPT_NOTARIZE_DOCUMENT, SCRIPT_IDENTITY_ISSUER_UPDATE:
 		return true
 	default:
 		return false
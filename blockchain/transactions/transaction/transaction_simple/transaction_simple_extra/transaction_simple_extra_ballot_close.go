@@ -0,0 +1,115 @@
+package transaction_simple_extra
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config/config_ballots"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/bn256"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionSimpleExtraBallotClose struct {
+	TransactionSimpleExtraInterface
+	BallotId           []byte
+	Results            []uint64
+	OptionsPrivateKeys [][]byte
+}
+
+func (this *TransactionSimpleExtraBallotClose) IncludeTransactionVin0(blockHeight uint64, txHash []byte, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+
+	b, err := dataStorage.Ballots.Get(string(this.BallotId))
+	if err != nil {
+		return
+	}
+	if b == nil {
+		return errors.New("Ballot doesn't exist")
+	}
+	if !bytes.Equal(b.Creator, plainAcc.Key) {
+		return errors.New("Only the creator can close the ballot")
+	}
+	if b.Closed {
+		return errors.New("Ballot was already closed")
+	}
+	if blockHeight <= b.EndHeight {
+		return errors.New("Ballot voting window has not ended yet")
+	}
+	if len(this.Results) != len(b.Options) {
+		return errors.New("Ballot results mismatch")
+	}
+
+	//the tally is verified by decrypting it with the revealed option private keys
+	for i := range this.OptionsPrivateKeys {
+
+		priv := new(crypto.BNRed).SetBytes(this.OptionsPrivateKeys[i])
+		if string(crypto.GPoint.ScalarMult(priv).EncodeCompressed()) != string(b.OptionsPublicKeys[i]) {
+			return fmt.Errorf("Private key doesn't match option %d", i)
+		}
+
+		tally := b.Tallies[i].Amount
+		decrypted := new(bn256.G1).Add(tally.Left, new(bn256.G1).Neg(new(bn256.G1).ScalarMult(tally.Right, priv.BigInt())))
+		expected := new(bn256.G1).ScalarMult(crypto.G, new(big.Int).SetUint64(this.Results[i]))
+		if decrypted.String() != expected.String() {
+			return fmt.Errorf("Result doesn't match the tally of option %d", i)
+		}
+	}
+
+	b.Closed = true
+	b.Results = this.Results
+	b.OptionsPrivateKeys = this.OptionsPrivateKeys
+
+	return dataStorage.Ballots.Update(string(this.BallotId), b)
+}
+
+func (this *TransactionSimpleExtraBallotClose) Validate(fee uint64) (err error) {
+	if len(this.BallotId) != config_coins.ASSET_LENGTH {
+		return errors.New("BallotId length is invalid")
+	}
+	if len(this.Results) < config_ballots.BALLOT_OPTIONS_MIN || len(this.Results) > config_ballots.BALLOT_OPTIONS_MAX {
+		return errors.New("Invalid number of results")
+	}
+	if len(this.Results) != len(this.OptionsPrivateKeys) {
+		return errors.New("Results and Private Keys Mismatch")
+	}
+	return
+}
+
+func (this *TransactionSimpleExtraBallotClose) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(this.BallotId)
+	w.WriteByte(byte(len(this.Results)))
+	for i := range this.Results {
+		w.WriteUvarint(this.Results[i])
+		w.Write(this.OptionsPrivateKeys[i])
+	}
+}
+
+func (this *TransactionSimpleExtraBallotClose) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if this.BallotId, err = r.ReadBytes(config_coins.ASSET_LENGTH); err != nil {
+		return
+	}
+
+	var n byte
+	if n, err = r.ReadByte(); err != nil {
+		return
+	}
+	if n > config_ballots.BALLOT_OPTIONS_MAX_BYTE {
+		return errors.New("Invalid number of results")
+	}
+	this.Results = make([]uint64, n)
+	this.OptionsPrivateKeys = make([][]byte, n)
+	for i := range this.Results {
+		if this.Results[i], err = r.ReadUvarint(); err != nil {
+			return
+		}
+		if this.OptionsPrivateKeys[i], err = r.ReadBytes(cryptography.PrivateKeySize); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/identities/identity"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config/config_identities"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionSimpleExtraIdentityAttestationIssue struct {
+	TransactionSimpleExtraInterface
+	PublicKey    []byte
+	ClaimHash    []byte
+	Jurisdiction string
+	Expiry       uint64
+}
+
+func (this *TransactionSimpleExtraIdentityAttestationIssue) IncludeTransactionVin0(blockHeight uint64, txHash []byte, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+
+	if err = dataStorage.VerifyIdentityIssuer(plainAcc.Key); err != nil {
+		return
+	}
+
+	if this.Expiry <= blockHeight {
+		return errors.New("Attestation is already expired")
+	}
+
+	id, err := dataStorage.GetOrCreateIdentity(this.PublicKey)
+	if err != nil {
+		return
+	}
+
+	if err = id.AddAttestation(&identity.IdentityAttestation{
+		Issuer:       plainAcc.Key,
+		ClaimHash:    this.ClaimHash,
+		Jurisdiction: this.Jurisdiction,
+		Expiry:       this.Expiry,
+		IssuedHeight: blockHeight,
+	}, blockHeight); err != nil {
+		return
+	}
+
+	return dataStorage.Identities.Update(string(this.PublicKey), id)
+}
+
+func (this *TransactionSimpleExtraIdentityAttestationIssue) Validate(fee uint64) error {
+	if len(this.PublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Public Key")
+	}
+	if len(this.ClaimHash) != cryptography.HashSize {
+		return errors.New("Invalid Claim Hash")
+	}
+	if len(this.Jurisdiction) == 0 || len(this.Jurisdiction) > config_identities.IDENTITY_JURISDICTION_MAX_LENGTH {
+		return errors.New("Invalid Jurisdiction")
+	}
+	return nil
+}
+
+func (this *TransactionSimpleExtraIdentityAttestationIssue) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(this.PublicKey)
+	w.Write(this.ClaimHash)
+	w.WriteString(this.Jurisdiction)
+	w.WriteUvarint(this.Expiry)
+}
+
+func (this *TransactionSimpleExtraIdentityAttestationIssue) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if this.PublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if this.ClaimHash, err = r.ReadBytes(cryptography.HashSize); err != nil {
+		return
+	}
+	if this.Jurisdiction, err = r.ReadString(uint64(config_identities.IDENTITY_JURISDICTION_MAX_LENGTH)); err != nil {
+		return
+	}
+	if this.Expiry, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	return
+}
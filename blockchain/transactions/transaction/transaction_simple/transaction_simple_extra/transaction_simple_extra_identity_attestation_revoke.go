@@ -0,0 +1,65 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionSimpleExtraIdentityAttestationRevoke struct {
+	TransactionSimpleExtraInterface
+	PublicKey []byte
+	ClaimHash []byte
+}
+
+func (this *TransactionSimpleExtraIdentityAttestationRevoke) IncludeTransactionVin0(blockHeight uint64, txHash []byte, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+
+	if err = dataStorage.VerifyIdentityIssuer(plainAcc.Key); err != nil {
+		return
+	}
+
+	id, err := dataStorage.Identities.Get(string(this.PublicKey))
+	if err != nil {
+		return
+	}
+	if id == nil {
+		return errors.New("Identity doesn't exist")
+	}
+
+	attestation := id.GetAttestation(plainAcc.Key, this.ClaimHash, blockHeight)
+	if attestation == nil {
+		return errors.New("Attestation was not found or it is expired")
+	}
+
+	attestation.Revoked = true
+	attestation.RevokedHeight = blockHeight
+
+	return dataStorage.Identities.Update(string(this.PublicKey), id)
+}
+
+func (this *TransactionSimpleExtraIdentityAttestationRevoke) Validate(fee uint64) error {
+	if len(this.PublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Public Key")
+	}
+	if len(this.ClaimHash) != cryptography.HashSize {
+		return errors.New("Invalid Claim Hash")
+	}
+	return nil
+}
+
+func (this *TransactionSimpleExtraIdentityAttestationRevoke) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(this.PublicKey)
+	w.Write(this.ClaimHash)
+}
+
+func (this *TransactionSimpleExtraIdentityAttestationRevoke) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if this.PublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if this.ClaimHash, err = r.ReadBytes(cryptography.HashSize); err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package transaction_simple_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+// an accredited issuer accredits a new issuer. The accreditation can be removed only by the issuer that granted it or by the issuer itself
+type TransactionSimpleExtraIdentityIssuerUpdate struct {
+	TransactionSimpleExtraInterface
+	PublicKey []byte
+	Issuer    bool
+}
+
+func (this *TransactionSimpleExtraIdentityIssuerUpdate) IncludeTransactionVin0(blockHeight uint64, txHash []byte, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+
+	if err = dataStorage.VerifyIdentityIssuer(plainAcc.Key); err != nil {
+		return
+	}
+
+	id, err := dataStorage.Identities.GetOrCreateIdentity(this.PublicKey)
+	if err != nil {
+		return
+	}
+
+	if this.Issuer {
+		if id.Issuer {
+			return errors.New("Public Key is already an accredited identity issuer")
+		}
+		//the issuers sign their txs with a plain account
+		var exists bool
+		if exists, err = dataStorage.PlainAccs.Exists(string(this.PublicKey)); err != nil {
+			return
+		}
+		if !exists {
+			return errors.New("Identity issuer requires a plain account")
+		}
+	} else {
+		if !id.Issuer {
+			return errors.New("Public Key is not an accredited identity issuer")
+		}
+		if !bytes.Equal(this.PublicKey, plainAcc.Key) && !bytes.Equal(id.AccreditedBy, plainAcc.Key) {
+			return errors.New("Only the issuer that accredited it can remove the accreditation")
+		}
+	}
+
+	id.SetIssuer(this.Issuer, plainAcc.Key)
+
+	return dataStorage.Identities.Update(string(this.PublicKey), id)
+}
+
+func (this *TransactionSimpleExtraIdentityIssuerUpdate) Validate(fee uint64) error {
+	if len(this.PublicKey) != cryptography.PublicKeySize {
+		return errors.New("Invalid Public Key")
+	}
+	return nil
+}
+
+func (this *TransactionSimpleExtraIdentityIssuerUpdate) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(this.PublicKey)
+	w.WriteBool(this.Issuer)
+}
+
+func (this *TransactionSimpleExtraIdentityIssuerUpdate) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if this.PublicKey, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+	if this.Issuer, err = r.ReadBool(); err != nil {
+		return
+	}
+	return
+}
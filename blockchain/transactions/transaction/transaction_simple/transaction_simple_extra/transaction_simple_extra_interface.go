@@ -7,7 +7,7 @@ import (
 )
 
 type TransactionSimpleExtraInterface interface {
-	IncludeTransactionVin0(blockHeight uint64, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) error
+	IncludeTransactionVin0(blockHeight uint64, txHash []byte, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) error
 	Serialize(w *advanced_buffers.BufferWriter, inclSignature bool)
 	Deserialize(r *advanced_buffers.BufferReader) error
 	Validate(fee uint64) error
@@ -0,0 +1,101 @@
+package transaction_simple_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/notarizations/notarization"
+	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
+	"pandora-pay/config/config_notarizations"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionSimpleExtraNotarizeDocument struct {
+	TransactionSimpleExtraInterface
+	DocumentHash    []byte
+	MimeType        string
+	Issuer          []byte //optional
+	IssuerSignature []byte //issuer signature of the DocumentHash
+}
+
+func (this *TransactionSimpleExtraNotarizeDocument) IncludeTransactionVin0(blockHeight uint64, txHash []byte, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+
+	exists, err := dataStorage.Notarizations.Exists(string(this.DocumentHash))
+	if err != nil {
+		return
+	}
+	if exists {
+		return errors.New("Document was already notarized")
+	}
+
+	return dataStorage.Notarizations.Create(string(this.DocumentHash), &notarization.Notarization{
+		DocumentHash:    this.DocumentHash,
+		MimeType:        this.MimeType,
+		Notary:          plainAcc.Key,
+		Issuer:          this.Issuer,
+		IssuerSignature: this.IssuerSignature,
+		TxHash:          txHash,
+		BlockHeight:     blockHeight,
+	})
+}
+
+func (this *TransactionSimpleExtraNotarizeDocument) VerifySignature() bool {
+	if len(this.Issuer) == 0 {
+		return true
+	}
+	return crypto.VerifySignature(this.DocumentHash, this.IssuerSignature, this.Issuer)
+}
+
+func (this *TransactionSimpleExtraNotarizeDocument) Validate(fee uint64) error {
+	if len(this.DocumentHash) != cryptography.HashSize {
+		return errors.New("Invalid Document Hash")
+	}
+	if len(this.MimeType) == 0 || len(this.MimeType) > config_notarizations.NOTARIZATION_MIME_TYPE_MAX_LENGTH {
+		return errors.New("Invalid Mime Type")
+	}
+	if len(this.Issuer) > 0 {
+		if len(this.Issuer) != cryptography.PublicKeySize {
+			return errors.New("Invalid Issuer")
+		}
+		if len(this.IssuerSignature) != cryptography.SignatureSize {
+			return errors.New("Invalid Issuer Signature")
+		}
+	} else if len(this.IssuerSignature) != 0 {
+		return errors.New("Issuer Signature without Issuer")
+	}
+	return nil
+}
+
+func (this *TransactionSimpleExtraNotarizeDocument) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(this.DocumentHash)
+	w.WriteString(this.MimeType)
+	w.WriteBool(len(this.Issuer) > 0)
+	if len(this.Issuer) > 0 {
+		w.Write(this.Issuer)
+		w.Write(this.IssuerSignature)
+	}
+}
+
+func (this *TransactionSimpleExtraNotarizeDocument) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	if this.DocumentHash, err = r.ReadBytes(cryptography.HashSize); err != nil {
+		return
+	}
+	if this.MimeType, err = r.ReadString(uint64(config_notarizations.NOTARIZATION_MIME_TYPE_MAX_LENGTH)); err != nil {
+		return
+	}
+
+	var hasIssuer bool
+	if hasIssuer, err = r.ReadBool(); err != nil {
+		return
+	}
+	if hasIssuer {
+		if this.Issuer, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+		if this.IssuerSignature, err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+			return
+		}
+	}
+	return
+}
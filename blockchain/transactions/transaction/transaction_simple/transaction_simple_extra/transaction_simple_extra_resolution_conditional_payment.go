@@ -19,7 +19,7 @@ type TransactionSimpleExtraResolutionConditionalPayment struct {
 	Signatures         [][]byte
 }
 
-func (this *TransactionSimpleExtraResolutionConditionalPayment) IncludeTransactionVin0(blockHeight uint64, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+func (this *TransactionSimpleExtraResolutionConditionalPayment) IncludeTransactionVin0(blockHeight uint64, txHash []byte, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
 
 	key := string(this.TxId) + "_" + strconv.Itoa(int(this.PayloadIndex))
 
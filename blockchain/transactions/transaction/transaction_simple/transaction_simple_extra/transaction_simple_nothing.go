@@ -14,7 +14,7 @@ type TransactionSimpleNothing struct {
 	TransactionSimpleExtraInterface
 }
 
-// func (this *TransactionSimpleNothing) IncludeTransactionVin0(blockHeight uint64, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
+// func (this *TransactionSimpleNothing) IncludeTransactionVin0(blockHeight uint64, txHash []byte, plainAcc *plain_account.PlainAccount, dataStorage *data_storage.DataStorage) (err error) {
 
 
 // 	return
@@ -6,6 +6,12 @@ const (
 	SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY ScriptType = iota
 	SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT
 	SCRIPT_NOTHING
+	SCRIPT_BALLOT_CLOSE
+	SCRIPT_IDENTITY_ATTESTATION_ISSUE
+	SCRIPT_IDENTITY_ATTESTATION_REVOKE
+	SCRIPT_IDENTITY_ATTESTATION_ROTATE
+	SCRIPT_NOTARIZE_DOCUMENT
+	SCRIPT_IDENTITY_ISSUER_UPDATE
 )
 
 func (t ScriptType) String() string {
@@ -16,6 +22,18 @@ func (t ScriptType) String() string {
 		return "SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT"
 	case SCRIPT_NOTHING:
 		return "SCRIPT_NOTHING"
+	case SCRIPT_BALLOT_CLOSE:
+		return "SCRIPT_BALLOT_CLOSE"
+	case SCRIPT_IDENTITY_ATTESTATION_ISSUE:
+		return "SCRIPT_IDENTITY_ATTESTATION_ISSUE"
+	case SCRIPT_IDENTITY_ATTESTATION_REVOKE:
+		return "SCRIPT_IDENTITY_ATTESTATION_REVOKE"
+	case SCRIPT_IDENTITY_ATTESTATION_ROTATE:
+		return "SCRIPT_IDENTITY_ATTESTATION_ROTATE"
+	case SCRIPT_NOTARIZE_DOCUMENT:
+		return "SCRIPT_NOTARIZE_DOCUMENT"
+	case SCRIPT_IDENTITY_ISSUER_UPDATE:
+		return "SCRIPT_IDENTITY_ISSUER_UPDATE"
 	default:
 		return "Unknown ScriptType"
 	}
@@ -8,6 +8,9 @@ const (
 	TX_END
 )
 
+//the serialized version of the txs having an ExpiryHeight is flagged. The transactions without it keep the old encoding
+const TX_VERSION_FLAG_EXPIRY = uint64(1 << 6)
+
 func (t TransactionVersion) String() string {
 	switch t {
 	case TX_SIMPLE:
@@ -193,7 +193,7 @@ func (payload *TransactionZetherPayload) IncludePayload(txHash []byte, payloadIn
 					update = true
 				}
 			} else { //recipient
-				if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT { //nothing
+				if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_BALLOT_VOTE { //nothing
 
 				} else if bytes.Equal(payload.Asset, config_coins.NATIVE_ASSET_FULL) && (reg.Staked || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD) {
 					if err = dataStorage.AddPendingStake(publicKey, echanges, blockHeight+config_stake.GetPendingStakeWindow(blockHeight)); err != nil {
@@ -222,6 +222,13 @@ func (payload *TransactionZetherPayload) IncludePayload(txHash []byte, payloadIn
 		}
 	}
 
+	if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_BALLOT_VOTE {
+		extra := payload.Extra.(*transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotVote)
+		if err = dataStorage.AddBallotVote(blockHeight, extra.BallotId, payload.Asset, payload.Parity, publicKeyList, echangesAll); err != nil {
+			return
+		}
+	}
+
 	if payload.Extra != nil {
 		if err = payload.Extra.AfterIncludeTxPayload(txHash, payload.Registrations, payloadIndex, payload.Asset, payload.BurnValue, payload.Statement, publicKeyList, blockHeight, dataStorage); err != nil {
 			return
@@ -278,7 +285,7 @@ func (payload *TransactionZetherPayload) Validate(payloadIndex byte) (err error)
 
 	switch payload.PayloadScript {
 	case transaction_zether_payload_script.SCRIPT_TRANSFER:
-	case transaction_zether_payload_script.SCRIPT_STAKING, transaction_zether_payload_script.SCRIPT_STAKING_REWARD, transaction_zether_payload_script.SCRIPT_SPEND, transaction_zether_payload_script.SCRIPT_ASSET_CREATE, transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND, transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
+	case transaction_zether_payload_script.SCRIPT_STAKING, transaction_zether_payload_script.SCRIPT_STAKING_REWARD, transaction_zether_payload_script.SCRIPT_SPEND, transaction_zether_payload_script.SCRIPT_ASSET_CREATE, transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE, transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND, transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT, transaction_zether_payload_script.SCRIPT_BALLOT_CREATE, transaction_zether_payload_script.SCRIPT_BALLOT_VOTE:
 		if payload.Extra == nil {
 			return errors.New("extra is not assigned")
 		}
@@ -357,6 +364,10 @@ func (payload *TransactionZetherPayload) Deserialize(r *advanced_buffers.BufferR
 		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraSpend{}
 	case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
 		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraConditionalPayment{}
+	case transaction_zether_payload_script.SCRIPT_BALLOT_CREATE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotCreate{}
+	case transaction_zether_payload_script.SCRIPT_BALLOT_VOTE:
+		payload.Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotVote{}
 	default:
 		return errors.New("INVALID SCRIPT TYPE")
 	}
@@ -0,0 +1,216 @@
+package transaction_zether_payload_extra
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts/account/account_balance_homomorphic"
+	"pandora-pay/blockchain/data_storage/ballots/ballot"
+	"pandora-pay/blockchain/data_storage/registrations"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/config/config_ballots"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionZetherPayloadExtraBallotCreate struct {
+	TransactionZetherPayloadExtraInterface
+	Title                         string
+	Options                       []string
+	OptionsPublicKeys             [][]byte //votes are encrypted to these keys, private keys are revealed when the ballot is closed. The holder of the private keys can decrypt the tallies at any time
+	OptionsRegistrationSignatures [][]byte
+	EligibilityAsset              []byte
+	StartHeight                   uint64
+	EndHeight                     uint64
+	Creator                       []byte //plain account that is allowed to close the ballot
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) GetBallotId(txHash []byte, payloadIndex byte) []byte {
+	list := advanced_buffers.NewBufferWriter()
+	list.WriteByte(payloadIndex)
+	list.Write(txHash)
+	return cryptography.RIPEMD(cryptography.SHA3(list.Bytes()))
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	if payloadExtra.EndHeight <= blockHeight {
+		return errors.New("Ballot voting window already ended")
+	}
+
+	exists, err := dataStorage.Asts.Exists(string(payloadExtra.EligibilityAsset))
+	if err != nil {
+		return
+	}
+	if !exists {
+		return errors.New("Ballot eligibility asset doesn't exist")
+	}
+
+	b := ballot.NewBallot(nil, 0)
+	b.Title = payloadExtra.Title
+	b.Options = payloadExtra.Options
+	b.OptionsPublicKeys = payloadExtra.OptionsPublicKeys
+	b.EligibilityAsset = payloadExtra.EligibilityAsset
+	b.StartHeight = payloadExtra.StartHeight
+	b.EndHeight = payloadExtra.EndHeight
+	b.Creator = payloadExtra.Creator
+	b.Tallies = make([]*account_balance_homomorphic.BalanceHomomorphic, len(payloadExtra.OptionsPublicKeys))
+
+	for i, publicKey := range payloadExtra.OptionsPublicKeys {
+
+		reg, err := dataStorage.Regs.Get(string(publicKey))
+		if err != nil {
+			return err
+		}
+		if reg == nil {
+			if _, err = dataStorage.CreateRegistration(publicKey, false, nil); err != nil {
+				return err
+			}
+		} else if reg.Staked || len(reg.SpendPublicKey) > 0 {
+			return errors.New("Ballot option public key should not be staked or have a spend public key")
+		}
+
+		if _, _, err = dataStorage.GetOrCreateAccount(payloadExtra.EligibilityAsset, publicKey, true); err != nil {
+			return err
+		}
+
+		if b.Tallies[i], err = account_balance_homomorphic.NewBalanceHomomorphicEmptyBalance(publicKey); err != nil {
+			return err
+		}
+	}
+
+	if err = b.Validate(); err != nil {
+		return
+	}
+
+	//existence verification is done in CreateBallot
+	return dataStorage.Ballots.CreateBallot(payloadExtra.GetBallotId(txHash, payloadIndex), b)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+
+	if !bytes.Equal(payloadAsset, config_coins.NATIVE_ASSET_FULL) {
+		return errors.New("payloadAsset must be NATIVE_ASSET_FULL")
+	}
+	if len(payloadExtra.Title) == 0 || len(payloadExtra.Title) > config_ballots.BALLOT_TITLE_MAX_LENGTH {
+		return errors.New("Ballot title length is invalid")
+	}
+	if len(payloadExtra.Options) < config_ballots.BALLOT_OPTIONS_MIN || len(payloadExtra.Options) > config_ballots.BALLOT_OPTIONS_MAX {
+		return errors.New("Ballot options length is invalid")
+	}
+	if len(payloadExtra.OptionsPublicKeys) != len(payloadExtra.Options) || len(payloadExtra.OptionsRegistrationSignatures) != len(payloadExtra.Options) {
+		return errors.New("Ballot options and public keys mismatch")
+	}
+	for i := range payloadExtra.Options {
+		if len(payloadExtra.Options[i]) == 0 || len(payloadExtra.Options[i]) > config_ballots.BALLOT_OPTION_MAX_LENGTH {
+			return errors.New("Ballot option length is invalid")
+		}
+	}
+
+	unique := make(map[string]bool)
+	for i := range payloadExtra.OptionsPublicKeys {
+		unique[string(payloadExtra.OptionsPublicKeys[i])] = true
+	}
+	if len(unique) != len(payloadExtra.OptionsPublicKeys) {
+		return errors.New("Ballot options public keys contain duplicates")
+	}
+
+	if len(payloadExtra.EligibilityAsset) != config_coins.ASSET_LENGTH {
+		return errors.New("Ballot eligibility asset is invalid")
+	}
+	if payloadExtra.StartHeight >= payloadExtra.EndHeight {
+		return errors.New("Ballot voting window is invalid")
+	}
+	if payloadExtra.EndHeight-payloadExtra.StartHeight > config_ballots.BALLOT_VOTING_WINDOW_MAX {
+		return errors.New("Ballot voting window is too long")
+	}
+	if len(payloadExtra.Creator) != cryptography.PublicKeySize {
+		return errors.New("Ballot creator is invalid")
+	}
+
+	return nil
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	for i := range payloadExtra.OptionsPublicKeys {
+		if !registrations.VerifyRegistration(payloadExtra.OptionsPublicKeys[i], false, nil, payloadExtra.OptionsRegistrationSignatures[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) ComputeAllKeys(out map[string]bool) {
+	for _, publicKey := range payloadExtra.OptionsPublicKeys {
+		out[string(publicKey)] = true
+	}
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.WriteString(payloadExtra.Title)
+	w.WriteByte(byte(len(payloadExtra.Options)))
+	for i := range payloadExtra.Options {
+		w.WriteString(payloadExtra.Options[i])
+		w.Write(payloadExtra.OptionsPublicKeys[i])
+		w.Write(payloadExtra.OptionsRegistrationSignatures[i])
+	}
+	w.WriteAsset(payloadExtra.EligibilityAsset)
+	w.WriteUvarint(payloadExtra.StartHeight)
+	w.WriteUvarint(payloadExtra.EndHeight)
+	w.Write(payloadExtra.Creator)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+
+	if payloadExtra.Title, err = r.ReadString(uint64(config_ballots.BALLOT_TITLE_MAX_LENGTH)); err != nil {
+		return
+	}
+
+	var n byte
+	if n, err = r.ReadByte(); err != nil {
+		return
+	}
+	if n > config_ballots.BALLOT_OPTIONS_MAX_BYTE {
+		return errors.New("Ballot has too many options")
+	}
+
+	payloadExtra.Options = make([]string, n)
+	payloadExtra.OptionsPublicKeys = make([][]byte, n)
+	payloadExtra.OptionsRegistrationSignatures = make([][]byte, n)
+	for i := range payloadExtra.Options {
+		if payloadExtra.Options[i], err = r.ReadString(uint64(config_ballots.BALLOT_OPTION_MAX_LENGTH)); err != nil {
+			return
+		}
+		if payloadExtra.OptionsPublicKeys[i], err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+			return
+		}
+		if payloadExtra.OptionsRegistrationSignatures[i], err = r.ReadBytes(cryptography.SignatureSize); err != nil {
+			return
+		}
+	}
+
+	if payloadExtra.EligibilityAsset, err = r.ReadAsset(); err != nil {
+		return
+	}
+	if payloadExtra.StartHeight, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if payloadExtra.EndHeight, err = r.ReadUvarint(); err != nil {
+		return
+	}
+	if payloadExtra.Creator, err = r.ReadBytes(cryptography.PublicKeySize); err != nil {
+		return
+	}
+
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotCreate) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
@@ -0,0 +1,53 @@
+package transaction_zether_payload_extra
+
+import (
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_registrations"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/advanced_buffers"
+)
+
+type TransactionZetherPayloadExtraBallotVote struct {
+	TransactionZetherPayloadExtraInterface
+	BallotId []byte
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotVote) BeforeIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotVote) AfterIncludeTxPayload(txHash []byte, payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, publicKeyList [][]byte, blockHeight uint64, dataStorage *data_storage.DataStorage) (err error) {
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotVote) ComputeAllKeys(out map[string]bool) {
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotVote) VerifyExtraSignature(hashForSignature []byte, payloadStatement *crypto.Statement) bool {
+	return false
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotVote) Validate(payloadRegistrations *transaction_zether_registrations.TransactionZetherDataRegistrations, payloadIndex byte, payloadAsset []byte, payloadBurnValue uint64, payloadStatement *crypto.Statement, payloadParity bool) error {
+	if len(payloadExtra.BallotId) != config_coins.ASSET_LENGTH {
+		return errors.New("BallotId length is invalid")
+	}
+	if payloadBurnValue != 0 {
+		return errors.New("Payload burn value must be zero")
+	}
+	return nil
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotVote) Serialize(w *advanced_buffers.BufferWriter, inclSignature bool) {
+	w.Write(payloadExtra.BallotId)
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotVote) Deserialize(r *advanced_buffers.BufferReader) (err error) {
+	payloadExtra.BallotId, err = r.ReadBytes(config_coins.ASSET_LENGTH)
+	return
+}
+
+func (payloadExtra *TransactionZetherPayloadExtraBallotVote) UpdateStatement(payloadStatement *crypto.Statement) error {
+	return nil
+}
@@ -11,6 +11,8 @@ const (
 	SCRIPT_ASSET_SUPPLY_INCREASE
 	SCRIPT_PLAIN_ACCOUNT_FUND
 	SCRIPT_CONDITIONAL_PAYMENT
+	SCRIPT_BALLOT_CREATE
+	SCRIPT_BALLOT_VOTE
 )
 
 func (t PayloadScriptType) String() string {
@@ -31,6 +33,10 @@ func (t PayloadScriptType) String() string {
 		return "SCRIPT_PLAIN_ACCOUNT_FUND"
 	case SCRIPT_CONDITIONAL_PAYMENT:
 		return "SCRIPT_CONDITIONAL_PAYMENT"
+	case SCRIPT_BALLOT_CREATE:
+		return "SCRIPT_BALLOT_CREATE"
+	case SCRIPT_BALLOT_VOTE:
+		return "SCRIPT_BALLOT_VOTE"
 	default:
 		return "Unknown ScriptType"
 	}
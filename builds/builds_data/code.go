@@ -48,6 +48,10 @@ func PrepareData(data []byte) (txData *TransactionsBuilderCreateZetherTxReq, tra
 			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraPlainAccountFund{}
 		case transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT:
 			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraConditionalPayment{}
+		case transaction_zether_payload_script.SCRIPT_BALLOT_CREATE:
+			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraBallotCreate{}
+		case transaction_zether_payload_script.SCRIPT_BALLOT_VOTE:
+			txData.Payloads[t].Extra = &wizard.WizardZetherPayloadExtraBallotVote{}
 		default:
 			err = errors.New("Invalid PayloadScriptType")
 			return
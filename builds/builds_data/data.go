@@ -44,4 +44,5 @@ type TransactionsBuilderCreateZetherTxReq struct {
 	Regs              map[string][]byte            `json:"regs"`
 	ChainKernelHeight uint64                       `json:"chainKernelHeight"`
 	ChainKernelHash   []byte                       `json:"chainKernelHash"`
+	ExpiryHeight      uint64                       `json:"expiryHeight"`
 }
@@ -17,7 +17,7 @@ func RouteTransactionsBuilderCreateZetherTx(req []byte) (any, error) {
 		return nil, err
 	}
 
-	tx, err := wizard.CreateZetherTx(transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, txData.ChainKernelHeight, txData.ChainKernelHash, publicKeyIndexes, feesFinal, ctx, func(status string) {})
+	tx, err := wizard.CreateZetherTx(transfers, emap, hasRollovers, ringsSenderMembers, ringsRecipientMembers, txData.ChainKernelHeight, txData.ChainKernelHash, publicKeyIndexes, feesFinal, txData.ExpiryHeight, ctx, func(status string) {})
 	if err != nil {
 		return nil, err
 	}
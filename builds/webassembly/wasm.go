@@ -75,18 +75,20 @@ func initialize() {
 			"generateNewAddress": js.FuncOf(generateNewAddress),
 		}),
 		"cryptography": js.ValueOf(map[string]any{
-			"HASH_SIZE":            js.ValueOf(cryptography.HashSize),
-			"PRIVATE_KEY_SIZE":     js.ValueOf(cryptography.PrivateKeySize),
-			"SEED_SIZE":            js.ValueOf(cryptography.SeedSize),
-			"PUBLIC_KEY_SIZE":      js.ValueOf(cryptography.PublicKeySize),
-			"SIGNATURE_SIZE":       js.ValueOf(cryptography.SignatureSize),
-			"RIPEMD_SIZE":          js.ValueOf(cryptography.RipemdSize),
-			"PUBLIC_KEY_HASH_SIZE": js.ValueOf(cryptography.PublicKeyHashSize),
-			"CHECK_SUM_SIZE":       js.ValueOf(cryptography.ChecksumSize),
-			"sha3":                 js.FuncOf(sha3),
-			"ripemd":               js.FuncOf(ripemd),
-			"sign":                 js.FuncOf(sign),
-			"verify":               js.FuncOf(verify),
+			"HASH_SIZE":               js.ValueOf(cryptography.HashSize),
+			"PRIVATE_KEY_SIZE":        js.ValueOf(cryptography.PrivateKeySize),
+			"SEED_SIZE":               js.ValueOf(cryptography.SeedSize),
+			"PUBLIC_KEY_SIZE":         js.ValueOf(cryptography.PublicKeySize),
+			"SIGNATURE_SIZE":          js.ValueOf(cryptography.SignatureSize),
+			"RIPEMD_SIZE":             js.ValueOf(cryptography.RipemdSize),
+			"PUBLIC_KEY_HASH_SIZE":    js.ValueOf(cryptography.PublicKeyHashSize),
+			"CHECK_SUM_SIZE":          js.ValueOf(cryptography.ChecksumSize),
+			"sha3":                    js.FuncOf(sha3),
+			"ripemd":                  js.FuncOf(ripemd),
+			"sign":                    js.FuncOf(sign),
+			"verify":                  js.FuncOf(verify),
+			"verifyMerkleProof":       js.FuncOf(verifyMerkleProof),
+			"verifyAccountStateProof": js.FuncOf(verifyAccountStateProof),
 		}),
 		"network": js.ValueOf(map[string]any{
 			"networkDisconnect":                      js.FuncOf(networkDisconnect),
@@ -99,6 +101,7 @@ func initialize() {
 			"getNetworkBlockInfo":                    js.FuncOf(getNetworkBlockInfo),
 			"getNetworkBlockWithTxs":                 js.FuncOf(getNetworkBlockWithTxs),
 			"getNetworkTx":                           js.FuncOf(getNetworkTx),
+			"getNetworkTxProof":                      js.FuncOf(getNetworkTxProof),
 			"getNetworkTxExists":                     js.FuncOf(getNetworkTxExists),
 			"getNetworkBlockExists":                  js.FuncOf(getNetworkBlockExists),
 			"getNetworkTxPreview":                    js.FuncOf(getNetworkTxPreview),
@@ -139,6 +142,12 @@ func initialize() {
 					"ScriptType": js.ValueOf(map[string]any{
 						"SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY":     js.ValueOf(uint64(transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY)),
 						"SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT": js.ValueOf(uint64(transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT)),
+						"SCRIPT_BALLOT_CLOSE":                   js.ValueOf(uint64(transaction_simple.SCRIPT_BALLOT_CLOSE)),
+						"SCRIPT_IDENTITY_ATTESTATION_ISSUE":     js.ValueOf(uint64(transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ISSUE)),
+						"SCRIPT_IDENTITY_ATTESTATION_REVOKE":    js.ValueOf(uint64(transaction_simple.SCRIPT_IDENTITY_ATTESTATION_REVOKE)),
+						"SCRIPT_IDENTITY_ATTESTATION_ROTATE":    js.ValueOf(uint64(transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ROTATE)),
+						"SCRIPT_NOTARIZE_DOCUMENT":              js.ValueOf(uint64(transaction_simple.SCRIPT_NOTARIZE_DOCUMENT)),
+						"SCRIPT_IDENTITY_ISSUER_UPDATE":         js.ValueOf(uint64(transaction_simple.SCRIPT_IDENTITY_ISSUER_UPDATE)),
 					}),
 				}),
 				"transactionZether": js.ValueOf(map[string]any{
@@ -151,6 +160,8 @@ func initialize() {
 						"SCRIPT_ASSET_SUPPLY_INCREASE": js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_ASSET_SUPPLY_INCREASE)),
 						"SCRIPT_PLAIN_ACCOUNT_FUND":    js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_PLAIN_ACCOUNT_FUND)),
 						"SCRIPT_CONDITIONAL_PAYMENT":   js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT)),
+						"SCRIPT_BALLOT_CREATE":         js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_BALLOT_CREATE)),
+						"SCRIPT_BALLOT_VOTE":           js.ValueOf(uint64(transaction_zether_payload_script.SCRIPT_BALLOT_VOTE)),
 					}),
 				}),
 			}),
@@ -177,6 +188,8 @@ func initialize() {
 						"SUBSCRIPTION_ASSET":                js.ValueOf(int(api_code_types.SUBSCRIPTION_ASSET)),
 						"SUBSCRIPTION_REGISTRATION":         js.ValueOf(int(api_code_types.SUBSCRIPTION_REGISTRATION)),
 						"SUBSCRIPTION_TRANSACTION":          js.ValueOf(int(api_code_types.SUBSCRIPTION_TRANSACTION)),
+						"SUBSCRIPTION_BLOCKS":               js.ValueOf(int(api_code_types.SUBSCRIPTION_BLOCKS)),
+						"SUBSCRIPTION_MEMPOOL":              js.ValueOf(int(api_code_types.SUBSCRIPTION_MEMPOOL)),
 					}),
 				}),
 			}),
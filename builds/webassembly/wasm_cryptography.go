@@ -1,10 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"encoding/base64"
+	"errors"
+	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/builds/webassembly/webassembly_utils"
 	"pandora-pay/cryptography"
 	"pandora-pay/cryptography/crypto"
+	"pandora-pay/cryptography/merkle_tree"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/store/state_tree"
 	"syscall/js"
 )
 
@@ -81,3 +88,75 @@ func verify(this js.Value, args []js.Value) interface{} {
 		return out, nil
 	})
 }
+
+// verifies the proof returned by tx/proof against the merkle hash of a block header
+func verifyMerkleProof(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		proof := &api_common.APITxProofReply{}
+		if err := webassembly_utils.UnmarshalBytes(args[0], proof); err != nil {
+			return nil, err
+		}
+
+		merkleHash, err := base64.StdEncoding.DecodeString(args[1].String())
+		if err != nil {
+			return nil, err
+		}
+
+		return merkle_tree.VerifyMerkleProof(proof.TxHash, proof.MerkleIndex, proof.MerklePath, merkleHash), nil
+	})
+}
+
+// verifies the proofs of an account reply (returned serialized) against the state root of a trusted block (serialized, from the header chain of the light client). The reply must be for the same block
+func verifyAccountStateProof(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		reply := &api_common.APIAccountReply{}
+		if err := webassembly_utils.UnmarshalBytes(args[0], reply); err != nil {
+			return nil, err
+		}
+
+		publicKey, err := base64.StdEncoding.DecodeString(args[1].String())
+		if err != nil {
+			return nil, err
+		}
+
+		serialized, err := base64.StdEncoding.DecodeString(args[2].String())
+		if err != nil {
+			return nil, err
+		}
+
+		blk := block.CreateEmptyBlock()
+		if err = blk.Deserialize(advanced_buffers.NewBufferReader(serialized)); err != nil {
+			return nil, err
+		}
+		if err = blk.Validate(); err != nil {
+			return nil, err
+		}
+		blk.BloomSerializedNow(serialized)
+
+		if blk.Version != block.BLOCK_VERSION_STATE_ROOT {
+			return nil, errors.New("Block doesn't commit a state root")
+		}
+
+		if !bytes.Equal(reply.StateBlockHash, blk.Bloom.Hash) || reply.StateBlockHeight != blk.Height || !bytes.Equal(reply.StateRoot, blk.StateRoot) {
+			return false, nil
+		}
+
+		if len(reply.AccsSerialized) != len(reply.AccsProofs) || len(reply.AccsSerialized) != len(reply.AccsExtra) {
+			return false, nil
+		}
+
+		for i := range reply.AccsSerialized {
+			if reply.AccsExtra[i] == nil || !state_tree.VerifyProof(blk.StateRoot, "accounts_"+string(reply.AccsExtra[i].Asset), publicKey, reply.AccsSerialized[i], reply.AccsProofs[i]) {
+				return false, nil
+			}
+		}
+
+		if !state_tree.VerifyProof(blk.StateRoot, "plainAccs", publicKey, reply.PlainAccSerialized, reply.PlainAccProof) {
+			return false, nil
+		}
+
+		return state_tree.VerifyProof(blk.StateRoot, "registrations", publicKey, reply.RegSerialized, reply.RegProof), nil
+	})
+}
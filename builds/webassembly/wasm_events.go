@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/base64"
 	"errors"
+	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/blockchain/data_storage/accounts/account"
 	"pandora-pay/blockchain/data_storage/assets/asset"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
@@ -133,6 +134,15 @@ func listenNetworkNotifications(this js.Value, args []js.Value) interface{} {
 					case api_code_types.SUBSCRIPTION_TRANSACTION:
 						object = data.Data
 						extra = &api_types.APISubscriptionNotificationTxExtra{}
+					case api_code_types.SUBSCRIPTION_BLOCKS:
+						blk := block.CreateEmptyBlock()
+						if err = blk.Deserialize(advanced_buffers.NewBufferReader(data.Data)); err != nil {
+							return
+						}
+						object = blk
+						extra = &api_types.APISubscriptionNotificationBlockExtra{}
+					case api_code_types.SUBSCRIPTION_MEMPOOL:
+						extra = &api_types.APISubscriptionNotificationMempoolExtra{}
 					default:
 						return //invalid
 					}
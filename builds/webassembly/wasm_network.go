@@ -259,6 +259,18 @@ func getNetworkTx(this js.Value, args []js.Value) interface{} {
 	})
 }
 
+func getNetworkTxProof(this js.Value, args []js.Value) interface{} {
+	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
+
+		request := &api_common.APITxProofRequest{}
+		if err := webassembly_utils.UnmarshalBytes(args[0], request); err != nil {
+			return nil, err
+		}
+
+		return webassembly_utils.ConvertToJSONBytes(network.SendJSONAwaitAnswer[api_common.APITxProofReply]([]byte("tx/proof"), request, nil, 0))
+	})
+}
+
 func getNetworkTxExists(this js.Value, args []js.Value) interface{} {
 	return webassembly_utils.PromiseFunction(func() (interface{}, error) {
 
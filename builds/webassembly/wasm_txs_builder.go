@@ -25,14 +25,15 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 		}
 
 		txData := &struct {
-			TxScript   transaction_simple.ScriptType `json:"txScript"`
-			Sender     string                        `json:"sender"`
-			Nonce      uint64                        `json:"nonce"`
-			Extra      wizard.WizardTxSimpleExtra    `json:"extra"`
-			Data       *wizard.WizardTransactionData `json:"data"`
-			Fee        *wizard.WizardTransactionFee  `json:"fee"`
-			FeeVersion bool                          `json:"feeVersion"`
-			Height     uint64                        `json:"height"`
+			TxScript     transaction_simple.ScriptType `json:"txScript"`
+			Sender       string                        `json:"sender"`
+			Nonce        uint64                        `json:"nonce"`
+			ExpiryHeight uint64                        `json:"expiryHeight"`
+			Extra        wizard.WizardTxSimpleExtra    `json:"extra"`
+			Data         *wizard.WizardTransactionData `json:"data"`
+			Fee          *wizard.WizardTransactionFee  `json:"fee"`
+			FeeVersion   bool                          `json:"feeVersion"`
+			Height       uint64                        `json:"height"`
 		}{}
 
 		//read txScript
@@ -49,6 +50,18 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 			txData.Extra = &wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{}
 		case transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT:
 			txData.Extra = &wizard.WizardTxSimpleExtraResolutionConditionalPayment{}
+		case transaction_simple.SCRIPT_BALLOT_CLOSE:
+			txData.Extra = &wizard.WizardTxSimpleExtraBallotClose{}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ISSUE:
+			txData.Extra = &wizard.WizardTxSimpleExtraIdentityAttestationIssue{}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_REVOKE:
+			txData.Extra = &wizard.WizardTxSimpleExtraIdentityAttestationRevoke{}
+		case transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ROTATE:
+			txData.Extra = &wizard.WizardTxSimpleExtraIdentityAttestationRotate{}
+		case transaction_simple.SCRIPT_NOTARIZE_DOCUMENT:
+			txData.Extra = &wizard.WizardTxSimpleExtraNotarizeDocument{}
+		case transaction_simple.SCRIPT_IDENTITY_ISSUER_UPDATE:
+			txData.Extra = &wizard.WizardTxSimpleExtraIdentityIssuerUpdate{}
 		default:
 			txData.Extra = nil
 			return nil, errors.New("Invalid Tx Simple Script")
@@ -63,6 +76,7 @@ func createSimpleTx(this js.Value, args []js.Value) interface{} {
 			txData.Data,
 			txData.Fee,
 			txData.Nonce,
+			txData.ExpiryHeight,
 			nil,
 		}
 
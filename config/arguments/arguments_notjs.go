@@ -8,7 +8,7 @@ package arguments
 var commands = `PANDORA PAY.
 
 Usage:
-  pandorapay [--pprof] [--network=network] [--debug] [--gui-type=type] [--forging] [--new-devnet] [--run-testnet-script] [--node-name=name] [--tcp-server-port=port] [--tcp-server-address=address] [--tcp-server-auto-tls-certificate] [--tcp-server-tls-cert-file=path] [--tcp-server-tls-key-file=path] [--instance=prefix] [--instance-id=id] [--set-genesis=genesis] [--create-new-genesis=args] [--store-wallet-type=type] [--store-chain-type=type] [--node-consensus=type] [--tcp-max-clients=limit] [--tcp-max-server-sockets=limit] [--node-provide-extended-info-app=bool] [--wallet-encrypt=args] [--wallet-decrypt=password] [--wallet-remove-encryption] [--wallet-export-shared-staked-address=args] [--wallet-import-secret-mnemonic=mnemonic] [--wallet-import-secret-entropy=entropy] [--hcaptcha-secret=args] [--faucet-testnet-enabled=args] [--delegator-enabled=bool] [--delegator-require-auth=bool] [--delegates-maximum=args] [--auth-users=args] [--light-computations] [--balance-decryptor-disable-init] [--balance-decryptor-table-size=size] [--tcp-connections-ready=threshold] [--exit] [--skip-init-sync] [--tcp-server-url=url] [--tcp-proxy=PROXY]
+  pandorapay [--pprof] [--network=network] [--debug] [--gui-type=type] [--forging] [--new-devnet] [--run-testnet-script] [--node-name=name] [--tcp-server-port=port] [--tcp-server-address=address] [--tcp-server-auto-tls-certificate] [--tcp-server-tls-cert-file=path] [--tcp-server-tls-key-file=path] [--instance=prefix] [--instance-id=id] [--set-genesis=genesis] [--create-new-genesis=args] [--store-wallet-type=type] [--store-chain-type=type] [--node-consensus=type] [--tcp-max-clients=limit] [--tcp-max-server-sockets=limit] [--node-provide-extended-info-app=bool] [--wallet-encrypt=args] [--wallet-decrypt=password] [--wallet-remove-encryption] [--wallet-export-shared-staked-address=args] [--wallet-import-secret-mnemonic=mnemonic] [--wallet-import-secret-entropy=entropy] [--hcaptcha-secret=args] [--faucet-testnet-enabled=args] [--delegator-enabled=bool] [--delegator-require-auth=bool] [--delegates-maximum=args] [--auth-users=args] [--auth-keys=args] [--auth-session-expiry=seconds] [--light-computations] [--balance-decryptor-disable-init] [--balance-decryptor-table-size=size] [--tcp-connections-ready=threshold] [--exit] [--skip-init-sync] [--tcp-server-url=url] [--tcp-proxy=PROXY] [--snapshot-export=path] [--snapshot-export-height=height] [--snapshot-import=path] [--snapshot-hash=hash] [--prune=blocks] [--mempool-max-size=bytes] [--mempool-max-txs=count] [--mempool-max-account-txs=count] [--mempool-tx-expiry-blocks=blocks] [--network-permissioned] [--network-allowlist=path] [--rate-limit-ip=requests] [--rate-limit-connection=requests] [--rate-limit-expensive=requests] [--rate-limit-consensus=requests]
   pandorapay -h | --help
   pandorapay -v | --version
 
@@ -24,11 +24,11 @@ Options:
   --run-testnet-script                               Run testnet script which will create dummy transactions in the network.
   --set-genesis=genesis                              Manually set the Genesis via a JSON. By using argument "file" it will read it via a file.
   --create-new-genesis=args                          Create a new Genesis. Useful for creating a new private testnet. Argument must be "0.stake,1.stake,2.stake"
-  --store-wallet-type=type                           Set Wallet Store Type. Accepted values: "bolt|bunt|bunt-memory|memory". [default: bolt]
-  --store-chain-type=type                            Set Chain Store Type. Accepted values: "bolt|bunt|bunt-memory|memory".  [default: bolt]
+  --store-wallet-type=type                           Set Wallet Store Type. Accepted values: "bolt|bunt|bunt-memory|memory|leveldb". [default: bolt]
+  --store-chain-type=type                            Set Chain Store Type. Accepted values: "bolt|bunt|bunt-memory|memory|leveldb".  [default: bolt]
   --forging                                          Start Forging blocks.
   --node-name=name                                   Change node name.
-  --node-consensus=type                              Consensus type. Accepted values: "full|app|none" [default: full].
+  --node-consensus=type                              Consensus type. Accepted values: "full|app|app-headers|none" [default: full]. app-headers requires --network-permissioned as the stake of the headers is not verified.
   --node-provide-extended-info-app=bool              Storing and serving additional info to wallet nodes. [default: true]. To enable, it requires full node
   --tcp-server-url=url                               TCP Server URL (schema, address, port, path).
   --tcp-server-port=port                             Change node tcp server port [default: 8080].
@@ -51,10 +51,27 @@ Options:
   --delegator-enabled=bool                           Enable Delegator. Will allow other users to Delegate to the node. Use "true" to enable it
   --delegator-require-auth=bool                      Delegator will require authentication.
   --delegates-maximum=args                           Maximum number of Delegates
-  --auth-users=args                                  Credential for Authenticated Users. Arguments must be a JSON "[{'user': 'username', 'pass': 'secret'}]".
+  --auth-users=args                                  Credential for Authenticated Users. Arguments must be a JSON "[{'user': 'username', 'pass': 'secret', 'role': 'admin'}]". The role is read, spender or admin. Admin when it is missing.
+  --auth-keys=args                                   Public keys (hex) of the Authenticated Users that login by signing a challenge. Arguments must be a JSON "[{'user': 'username', 'publicKey': 'hex', 'role': 'admin'}]".
+  --auth-session-expiry=seconds                      Expiry of the sessions opened with a key login. [default: 3600]
   --light-computations                               Reduces the computations for a testnet node.
   --balance-decryptor-disable-init                   Disable first balance decryptor initialization. 
   --balance-decryptor-table-size=size                Balance Decryptor initial table size. [default: 23]
   --exit                                             Exit node.
   --skip-init-sync                                   Skip sync wait at when the node started. Useful when creating a new testnet.
+  --snapshot-export=path                             Export a snapshot of the blockchain state and the last blocks to the file.
+  --snapshot-export-height=height                    Height of the last block of the exported snapshot. By default, the last block of the chain.
+  --snapshot-import=path                             Import a snapshot into an empty blockchain store before the node starts.
+  --snapshot-hash=hash                               Expected hash (hex) of the last block of the imported snapshot. It is required by --snapshot-import.
+  --prune=blocks                                     Keep the transactions and the extended info only for the last blocks. The headers and the state are kept.
+  --mempool-max-size=bytes                           Maximum size of the pending txs. The lowest fee per byte txs are evicted when it is full. [default: 268435456]
+  --mempool-max-txs=count                            Maximum number of pending txs. [default: 100000]
+  --mempool-max-account-txs=count                    Maximum number of pending txs of the same sender. [default: 64]
+  --mempool-tx-expiry-blocks=blocks                  Pending txs older than this number of blocks are removed. Use 0 to disable it. [default: 2000]
+  --network-permissioned                             Accept only the nodes whose key is in the allowlist. Every node proves its key in the handshake, bound to the TLS session, so the nodes require wss and a TLS certificate.
+  --network-allowlist=path                           File with the allowed node public keys (hex), one per line. It is reloaded when the file changes.
+  --rate-limit-ip=requests                           Requests per second of an ip over http and websockets. Use 0 to disable it. [default: 100]
+  --rate-limit-connection=requests                   Requests per second of a websocket connection. Use 0 to disable it. [default: 50]
+  --rate-limit-expensive=requests                    Requests per second of the expensive methods (accounts/by-keys, tx-preview, tx/simulate...) for an ip and for a connection. Use 0 to disable it. [default: 5]
+  --rate-limit-consensus=requests                    Requests per second of the consensus methods (block, block-hash, tx-raw...) of a connection after the handshake. They are not counted in the other limits. Use 0 to disable it. [default: 500]
 `
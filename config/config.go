@@ -9,6 +9,7 @@ import (
 	"pandora-pay/config/config_forging"
 	"pandora-pay/config/config_nodes"
 	"runtime"
+	"strconv"
 	"time"
 )
 
@@ -51,6 +52,8 @@ const (
 	DIFFICULTY_BLOCK_WINDOW uint64 = 10
 	FORK_MAX_UNCLE_ALLOWED  uint64 = 60
 	FORK_MAX_DOWNLOAD       uint64 = 20
+	HEADERS_MAX_DOWNLOAD    uint64 = 500
+	SNAPSHOT_BLOCKS         uint64 = 100 //complete blocks kept in a snapshot. It must be greater than FORK_MAX_UNCLE_ALLOWED
 )
 
 var (
@@ -58,11 +61,13 @@ var (
 	NETWORK_SELECTED_BYTE_PREFIX     = MAIN_NET_NETWORK_BYTE_PREFIX
 	NETWORK_SELECTED_NAME            = MAIN_NET_NETWORK_NAME
 	NETWORK_SELECTED_SEEDS           = MAIN_NET_SEED_NODES
+	NETWORK_SELECTED_FORKS           = MAIN_NET_FORKS
 	NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.MAIN_NET_DELEGATOR_NODES
 )
 
 var (
 	API_MEMPOOL_MAX_TRANSACTIONS = 50
+	API_MEMPOOL_NEW_TXS_MAX      = 1000
 	API_ACCOUNT_MAX_TXS          = uint64(10)
 	API_ASSETS_INFO_MAX_RESULTS  = 10
 )
@@ -77,6 +82,15 @@ var (
 var (
 	NODE_PROVIDE_EXTENDED_INFO_APP bool
 	NODE_CONSENSUS                 NodeConsensusType = NODE_CONSENSUS_TYPE_FULL
+	NODE_CONSENSUS_APP_HEADERS     bool              //app node which downloads and validates only the block headers
+	PRUNE_BLOCKS                   uint64            //number of last blocks with complete data. 0 means disabled
+)
+
+var (
+	MEMPOOL_MAX_SIZE         = uint64(256 * 1024 * 1024) //bytes of all pending txs
+	MEMPOOL_MAX_TXS          = uint64(100000)
+	MEMPOOL_MAX_ACCOUNT_TXS  = uint64(64)   //pending txs having the same public key as input
+	MEMPOOL_TX_EXPIRY_BLOCKS = uint64(2000) //pending txs older than this are removed. 0 means disabled
 )
 
 var (
@@ -91,12 +105,14 @@ func InitConfig() (err error) {
 	} else if arguments.Arguments["--network"] == "testnet" {
 		NETWORK_SELECTED = TEST_NET_NETWORK_BYTE
 		NETWORK_SELECTED_SEEDS = TEST_NET_SEED_NODES
+		NETWORK_SELECTED_FORKS = TEST_NET_FORKS
 		NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.TEST_NET_DELEGATOR_NODES
 		NETWORK_SELECTED_NAME = TEST_NET_NETWORK_NAME
 		NETWORK_SELECTED_BYTE_PREFIX = TEST_NET_NETWORK_BYTE_PREFIX
 	} else if arguments.Arguments["--network"] == "devnet" {
 		NETWORK_SELECTED = DEV_NET_NETWORK_BYTE
 		NETWORK_SELECTED_SEEDS = DEV_NET_SEED_NODES
+		NETWORK_SELECTED_FORKS = DEV_NET_FORKS
 		NETWORK_SELECTED_DELEGATOR_NODES = config_nodes.DEV_NET_DELEGATOR_NODES
 		NETWORK_SELECTED_NAME = DEV_NET_NETWORK_NAME
 		NETWORK_SELECTED_BYTE_PREFIX = DEV_NET_NETWORK_BYTE_PREFIX
@@ -121,12 +137,40 @@ func InitConfig() (err error) {
 		}
 	case "app":
 		NODE_CONSENSUS = NODE_CONSENSUS_TYPE_APP
+	case "app-headers":
+		NODE_CONSENSUS = NODE_CONSENSUS_TYPE_APP
+		NODE_CONSENSUS_APP_HEADERS = true
 	case "none":
 		NODE_CONSENSUS = NODE_CONSENSUS_TYPE_NONE
 	default:
 		return errors.New("invalid consensus argument")
 	}
 
+	if arguments.Arguments["--prune"] != nil {
+		if NODE_CONSENSUS != NODE_CONSENSUS_TYPE_FULL {
+			return errors.New("--prune requires a full node")
+		}
+		if PRUNE_BLOCKS, err = strconv.ParseUint(arguments.Arguments["--prune"].(string), 10, 64); err != nil {
+			return
+		}
+		if PRUNE_BLOCKS < FORK_MAX_UNCLE_ALLOWED {
+			return errors.New("--prune must keep at least " + strconv.FormatUint(FORK_MAX_UNCLE_ALLOWED, 10) + " blocks")
+		}
+	}
+
+	for name, value := range map[string]*uint64{
+		"--mempool-max-size":         &MEMPOOL_MAX_SIZE,
+		"--mempool-max-txs":          &MEMPOOL_MAX_TXS,
+		"--mempool-max-account-txs":  &MEMPOOL_MAX_ACCOUNT_TXS,
+		"--mempool-tx-expiry-blocks": &MEMPOOL_TX_EXPIRY_BLOCKS,
+	} {
+		if arguments.Arguments[name] != nil {
+			if *value, err = strconv.ParseUint(arguments.Arguments[name].(string), 10, 64); err != nil {
+				return
+			}
+		}
+	}
+
 	if err = config_nodes.InitConfig(); err != nil {
 		return
 	}
@@ -0,0 +1,10 @@
+package config_ballots
+
+var (
+	BALLOT_TITLE_MAX_LENGTH  = 100
+	BALLOT_OPTIONS_MIN       = 2
+	BALLOT_OPTIONS_MAX       = 32
+	BALLOT_OPTION_MAX_LENGTH = 100
+	BALLOT_VOTING_WINDOW_MAX = uint64(1000000)
+	BALLOT_OPTIONS_MAX_BYTE  = byte(32) //the options count is serialized as a byte
+)
@@ -4,8 +4,18 @@ var (
 	FEE_PER_BYTE             = uint64(10)
 	FEE_PER_BYTE_ZETHER      = uint64(20)
 	FEE_PER_BYTE_EXTRA_SPACE = uint64(100)
+	FEE_ESTIMATE_BLOCKS      = uint64(20) //last blocks used to estimate the fees
+	FEE_REPLACE_MIN_INCREASE = uint64(10) //percentage required to replace a pending tx with the same nonce
 )
 
+func ComputeReplaceFeePerByte(feePerByte uint64) uint64 {
+	increase := feePerByte * FEE_REPLACE_MIN_INCREASE / 100
+	if increase == 0 {
+		increase = 1
+	}
+	return feePerByte + increase
+}
+
 func ComputeTxFee(size, feePerByte, extraSpace, feePerByeExtraSpace uint64) uint64 {
 	return size*feePerByte + extraSpace*feePerByeExtraSpace
 }
@@ -0,0 +1,6 @@
+package config_identities
+
+var (
+	IDENTITY_JURISDICTION_MAX_LENGTH = 64
+	IDENTITY_ATTESTATIONS_MAX        = 64
+)
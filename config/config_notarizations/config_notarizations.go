@@ -0,0 +1,5 @@
+package config_notarizations
+
+var (
+	NOTARIZATION_MIME_TYPE_MAX_LENGTH = 128
+)
@@ -0,0 +1,25 @@
+package config
+
+//the consensus changes are activated starting with these heights. The blocks below them keep the previous rules
+type Forks struct {
+	StateRoot uint64 `json:"stateRoot" msgpack:"stateRoot"` //the blocks commit the state root
+	TxExpiry  uint64 `json:"txExpiry" msgpack:"txExpiry"`   //the txs can have an expiry height
+}
+
+var (
+	MAIN_NET_FORKS = &Forks{
+		StateRoot: 2500000,
+		TxExpiry:  2500000,
+	}
+
+	TEST_NET_FORKS = &Forks{
+		StateRoot: 2500000,
+		TxExpiry:  2500000,
+	}
+
+	//the devnets are created again often, so the changes are activated early
+	DEV_NET_FORKS = &Forks{
+		StateRoot: 500,
+		TxExpiry:  500,
+	}
+)
@@ -1,6 +1,8 @@
 package merkle_tree
 
 import (
+	"bytes"
+	"errors"
 	"math"
 	"pandora-pay/cryptography"
 )
@@ -65,3 +67,48 @@ func MerkleRoot(hashes [][]byte) []byte {
 	merkles := buildMerkleTree(hashes)
 	return merkles[len(merkles)-1] //return last element
 }
+
+// MerkleProof returns the sibling hashes from the leaf up to the root
+func MerkleProof(hashes [][]byte, index int) ([][]byte, error) {
+
+	if index < 0 || index >= len(hashes) {
+		return nil, errors.New("Merkle index is invalid")
+	}
+
+	merkles := buildMerkleTree(hashes)
+
+	proof := make([][]byte, 0)
+	levelStart, levelSize := 0, roundNextPowerOfTwo(len(hashes))
+	for levelSize > 1 {
+		sibling := merkles[levelStart+(index^1)]
+		if sibling == nil {
+			sibling = merkles[levelStart+index] //the node was hashed with itself
+		}
+		proof = append(proof, sibling)
+
+		levelStart += levelSize
+		levelSize /= 2
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+func VerifyMerkleProof(hash []byte, index int, proof [][]byte, root []byte) bool {
+
+	if index < 0 || index >= 1<<uint(len(proof)) {
+		return false
+	}
+
+	current := hash
+	for _, sibling := range proof {
+		if index&1 == 0 {
+			current = hashMerkleNode(append([]byte{}, current...), sibling)
+		} else {
+			current = hashMerkleNode(append([]byte{}, sibling...), current)
+		}
+		index /= 2
+	}
+
+	return bytes.Equal(current, root)
+}
@@ -18,3 +18,26 @@ func TestMerkleRoot(t *testing.T) {
 	assert.Equal(t, root, hash, "Merkle Tree Hashes are invalid")
 
 }
+
+func TestMerkleProof(t *testing.T) {
+
+	for count := 1; count < 12; count++ {
+
+		hashes := make([][]byte, count)
+		for i := range hashes {
+			hashes[i] = cryptography.RandomHash()
+		}
+
+		root := MerkleRoot(hashes)
+
+		for i := range hashes {
+			proof, err := MerkleProof(hashes, i)
+			assert.Nil(t, err)
+			assert.True(t, VerifyMerkleProof(hashes[i], i, proof, root), "Merkle Proof is invalid")
+			assert.False(t, VerifyMerkleProof(cryptography.RandomHash(), i, proof, root), "Merkle Proof should be invalid")
+		}
+	}
+
+	_, err := MerkleProof([][]byte{cryptography.RandomHash()}, 1)
+	assert.NotNil(t, err)
+}
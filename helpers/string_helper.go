@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"fmt"
 	"math/rand"
 )
 
@@ -13,3 +14,8 @@ func RandString(n int) string {
 	}
 	return string(b)
 }
+
+//fixed length, so the lexicographic order of the keys is the same as the numeric order
+func FormatIndexKey(index uint64) string {
+	return fmt.Sprintf("%020d", index)
+}
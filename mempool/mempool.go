@@ -3,6 +3,7 @@ package mempool
 import (
 	"context"
 	"errors"
+	"fmt"
 	"pandora-pay/blockchain/transactions/transaction"
 	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
@@ -25,6 +26,8 @@ type mempoolTx struct {
 	Mine        bool                     `json:"mine" msgpack:"mine"`
 	FeePerByte  uint64                   `json:"feePerByte" msgpack:"feePerByte"`
 	ChainHeight uint64                   `json:"chainHeight" msgpack:"chainHeight"`
+	replaces    *mempoolTx               //pending tx with the same nonce that will be evicted
+	feeIndex    int                      //position in the fee index of the mempool thread
 }
 
 type Mempool struct {
@@ -36,6 +39,8 @@ type Mempool struct {
 	removeTransactionsCn      chan *MempoolWorkerRemoveTxs
 	insertTransactionsCn      chan *MempoolWorkerInsertTxs
 	Txs                       *MempoolTxs
+	feeEstimateBlocks         *generics.Value[*feeEstimateBlocksSamples]
+	store                     *mempoolStore
 	OnBroadcastNewTransaction func([]*transaction.Transaction, bool, bool, advanced_connection_types.UUID, context.Context) []error
 }
 
@@ -45,7 +50,7 @@ func (mempool *Mempool) ContinueProcessing(continueProcessingType ContinueProces
 
 func (mempool *Mempool) RemoveInsertedTxsFromBlockchain(txs []string) bool {
 	answerCn := make(chan bool)
-	mempool.removeTransactionsCn <- &MempoolWorkerRemoveTxs{txs, answerCn}
+	mempool.removeTransactionsCn <- &MempoolWorkerRemoveTxs{txs, true, answerCn}
 	return <-answerCn
 }
 
@@ -89,13 +94,22 @@ func (mempool *Mempool) processTxsToMempool(txs []*transaction.Transaction, heig
 			for _, payload := range txBase.Payloads {
 				if payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING_REWARD || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_STAKING {
 					errs[i] = errors.New("Transaction is not accepted in the mempool")
-					return
+					break
 				}
 			}
+			if errs[i] != nil {
+				continue
+			}
 		}
 
+		//an invalid tx must not stop the processing of the others
 		if errs[i] = txs_validator.TxsValidator.ValidateTx(tx); errs[i] != nil {
-			return
+			continue
+		}
+
+		if tx.IsExpired(height) {
+			errs[i] = errors.New("Transaction has expired")
+			continue
 		}
 
 		if mempool.Txs.Exists(tx.Bloom.HashStr) {
@@ -139,11 +153,22 @@ func (mempool *Mempool) processTxsToMempool(txs []*transaction.Transaction, heig
 			}
 		}
 
+		var replaces *mempoolTx
+		if tx.Version == transaction_type.TX_SIMPLE {
+			if txBase := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple); txBase.HasVin() {
+				if replaces = mempool.getTxSimpleByNonce(txBase.Vin.PublicKey, txBase.Nonce); replaces != nil && computedFeePerByte < config_fees.ComputeReplaceFeePerByte(replaces.FeePerByte) {
+					errs[i] = fmt.Errorf("A transaction with the same nonce is pending. The fee per byte has to be at least %d to replace it", config_fees.ComputeReplaceFeePerByte(replaces.FeePerByte))
+					continue
+				}
+			}
+		}
+
 		finalTxs[i] = &mempoolTx{
 			Tx:          tx,
 			Added:       time.Now().Unix(),
 			FeePerByte:  computedFeePerByte,
 			ChainHeight: height,
+			replaces:    replaces,
 		}
 
 	}
@@ -168,6 +193,13 @@ func (mempool *Mempool) AddTxsToMempool(txs []*transaction.Transaction, height u
 
 				var errorResult error
 
+				//the replaced tx is evicted before, otherwise the new one would fail because of the nonce
+				if finalTx.replaces != nil {
+					answerCn := make(chan bool)
+					mempool.removeTransactionsCn <- &MempoolWorkerRemoveTxs{[]string{finalTx.replaces.Tx.Bloom.HashStr}, false, answerCn}
+					<-answerCn
+				}
+
 				if awaitAnswer {
 					answerCn := make(chan error)
 					mempool.addTransactionCn <- &MempoolWorkerAddTx{finalTx, answerCn}
@@ -188,17 +220,19 @@ func (mempool *Mempool) AddTxsToMempool(txs []*transaction.Transaction, height u
 	if exceptSocketUUID != advanced_connection_types.UUID_SKIP_ALL {
 
 		broadcastTxs := make([]*transaction.Transaction, 0)
-		for _, finalTx := range finalTxs {
+		broadcastIndexes := make([]int, 0)
+		for i, finalTx := range finalTxs {
 			if finalTx != nil {
 				broadcastTxs = append(broadcastTxs, finalTx.Tx)
+				broadcastIndexes = append(broadcastIndexes, i)
 			}
 		}
 
 		errors2 := mempool.OnBroadcastNewTransaction(broadcastTxs, justCreated, awaitBroadcasting, exceptSocketUUID, ctx)
 		for i, err := range errors2 {
 			if err != nil {
-				errs[i] = err
-				finalTxs[i] = nil
+				errs[broadcastIndexes[i]] = err
+				finalTxs[broadcastIndexes[i]] = nil
 			}
 		}
 
@@ -246,10 +280,12 @@ func CreateMempool() (*Mempool, error) {
 		make(chan *MempoolWorkerRemoveTxs),
 		make(chan *MempoolWorkerInsertTxs),
 		createMempoolTxs(),
+		&generics.Value[*feeEstimateBlocksSamples]{},
+		createMempoolStore(),
 		nil,
 	}
 
-	worker := new(mempoolWorker)
+	worker := &mempoolWorker{nil, mempool.store}
 	recovery.SafeGo(func() {
 		worker.processing(mempool.newWorkCn, mempool.SuspendProcessingCn, mempool.ContinueProcessingCn, mempool.addTransactionCn, mempool.insertTransactionsCn, mempool.removeTransactionsCn, mempool.Txs)
 	})
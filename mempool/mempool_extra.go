@@ -87,28 +87,14 @@ func (mempool *Mempool) CountInputTxs(publicKey []byte) uint64 {
 	return count
 }
 
-func (mempool *Mempool) GetNonce(publicKey []byte, nonce uint64) uint64 {
-
-	txs := mempool.Txs.GetTxsList()
-
-	nonces := make(map[uint64]bool)
-	for _, tx := range txs {
-		if tx.Tx.Version == transaction_type.TX_SIMPLE {
-			base := tx.Tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
-			if base.HasVin() && bytes.Equal(base.Vin.PublicKey, publicKey) {
-				nonces[base.Nonce] = true
-			}
-		}
-	}
+func (mempool *Mempool) getTxSimpleByNonce(publicKey []byte, nonce uint64) *mempoolTx {
+	return mempool.Txs.GetSenderTx(publicKey, nonce)
+}
 
-	for {
-		if nonces[nonce] {
-			nonce += 1
-		} else {
-			break
-		}
+func (mempool *Mempool) GetNonce(publicKey []byte, nonce uint64) uint64 {
+	for mempool.Txs.GetSenderTx(publicKey, nonce) != nil {
+		nonce += 1
 	}
-
 	return nonce
 }
 
@@ -0,0 +1,150 @@
+package mempool
+
+import (
+	"bytes"
+	"encoding/binary"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/config/config_fees"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
+	"strconv"
+)
+
+type FeeEstimate struct {
+	Low    uint64 `json:"low" msgpack:"low"`
+	Normal uint64 `json:"normal" msgpack:"normal"`
+	High   uint64 `json:"high" msgpack:"high"`
+}
+
+type FeesEstimate struct {
+	Simple *FeeEstimate `json:"simple" msgpack:"simple"`
+	Zether *FeeEstimate `json:"zether" msgpack:"zether"`
+}
+
+//the samples of the last blocks are cached until the chain changes
+type feeEstimateBlocksSamples struct {
+	chainHash []byte
+	simple    []uint64
+	zether    []uint64
+}
+
+//the zether payloads pay only for their own bytes, so the sum of their fees is the fee of the whole tx
+func getTxFeePerByte(tx *transaction.Transaction, size uint64) uint64 {
+	fee, err := tx.GetAllFee()
+	if err != nil || size == 0 {
+		return 0
+	}
+	return fee / size
+}
+
+func loadFeeEstimateBlocksSamples(reader store_db_interface.StoreDBTransactionInterface, chainHash []byte) (*feeEstimateBlocksSamples, error) {
+
+	samples := &feeEstimateBlocksSamples{chainHash, []uint64{}, []uint64{}}
+
+	chainHeight, _ := binary.Uvarint(reader.Get("chainHeight"))
+
+	start := uint64(0)
+	if chainHeight > config_fees.FEE_ESTIMATE_BLOCKS {
+		start = chainHeight - config_fees.FEE_ESTIMATE_BLOCKS
+	}
+
+	for height := start; height < chainHeight; height++ {
+
+		//pruned or header only nodes don't have the txs
+		data := reader.Get("blockTxs" + strconv.FormatUint(height, 10))
+		if data == nil {
+			continue
+		}
+
+		txHashes := [][]byte{}
+		if err := msgpack.Unmarshal(data, &txHashes); err != nil {
+			return nil, err
+		}
+
+		for _, txHash := range txHashes {
+
+			if data = reader.Get("tx:" + string(txHash)); data == nil {
+				continue
+			}
+
+			tx := &transaction.Transaction{}
+			if err := tx.Deserialize(advanced_buffers.NewBufferReader(data)); err != nil {
+				return nil, err
+			}
+
+			if feePerByte := getTxFeePerByte(tx, uint64(len(data))); feePerByte > 0 {
+				switch tx.Version {
+				case transaction_type.TX_SIMPLE:
+					samples.simple = append(samples.simple, feePerByte)
+				case transaction_type.TX_ZETHER:
+					samples.zether = append(samples.zether, feePerByte)
+				}
+			}
+		}
+	}
+
+	return samples, nil
+}
+
+func computeFeeEstimate(samples []uint64, minimum uint64) *FeeEstimate {
+
+	if len(samples) == 0 {
+		return &FeeEstimate{minimum, minimum, minimum}
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i] < samples[j]
+	})
+
+	percentile := func(p int) uint64 {
+		value := samples[(len(samples)-1)*p/100]
+		if value < minimum {
+			return minimum
+		}
+		return value
+	}
+
+	return &FeeEstimate{percentile(25), percentile(50), percentile(90)}
+}
+
+func (mempool *Mempool) EstimateFees() (*FeesEstimate, error) {
+
+	blocksSamples := mempool.feeEstimateBlocks.Load()
+
+	if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		chainHash := reader.Get("chainHash")
+		if blocksSamples == nil || !bytes.Equal(blocksSamples.chainHash, chainHash) {
+			if blocksSamples, err = loadFeeEstimateBlocksSamples(reader, chainHash); err != nil {
+				return
+			}
+			mempool.feeEstimateBlocks.Store(blocksSamples)
+		}
+		return
+	}); err != nil {
+		return nil, err
+	}
+
+	simple := append([]uint64{}, blocksSamples.simple...)
+	zether := append([]uint64{}, blocksSamples.zether...)
+
+	for _, tx := range mempool.Txs.GetTxsList() {
+		if tx.FeePerByte == 0 {
+			continue
+		}
+		switch tx.Tx.Version {
+		case transaction_type.TX_SIMPLE:
+			simple = append(simple, tx.FeePerByte)
+		case transaction_type.TX_ZETHER:
+			zether = append(zether, tx.FeePerByte)
+		}
+	}
+
+	return &FeesEstimate{
+		computeFeeEstimate(simple, config_fees.FEE_PER_BYTE),
+		computeFeeEstimate(zether, config_fees.FEE_PER_BYTE_ZETHER),
+	}, nil
+}
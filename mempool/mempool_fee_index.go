@@ -0,0 +1,59 @@
+package mempool
+
+import (
+	"container/heap"
+)
+
+//the txs ordered by the fee per byte, so the lowest one is found without scanning the whole mempool
+type mempoolFeeIndex []*mempoolTx
+
+func (index mempoolFeeIndex) Len() int {
+	return len(index)
+}
+
+func (index mempoolFeeIndex) Less(i, j int) bool {
+	return index[i].FeePerByte < index[j].FeePerByte
+}
+
+func (index mempoolFeeIndex) Swap(i, j int) {
+	index[i], index[j] = index[j], index[i]
+	index[i].feeIndex = i
+	index[j].feeIndex = j
+}
+
+func (index *mempoolFeeIndex) Push(x any) {
+	tx := x.(*mempoolTx)
+	tx.feeIndex = len(*index)
+	*index = append(*index, tx)
+}
+
+func (index *mempoolFeeIndex) Pop() any {
+	old := *index
+	tx := old[len(old)-1]
+	old[len(old)-1] = nil
+	*index = old[:len(old)-1]
+	return tx
+}
+
+func (index *mempoolFeeIndex) contains(tx *mempoolTx) bool {
+	return tx.feeIndex < len(*index) && (*index)[tx.feeIndex] == tx
+}
+
+func (index *mempoolFeeIndex) insert(tx *mempoolTx) {
+	if !index.contains(tx) {
+		heap.Push(index, tx)
+	}
+}
+
+func (index *mempoolFeeIndex) remove(tx *mempoolTx) {
+	if index.contains(tx) {
+		heap.Remove(index, tx.feeIndex)
+	}
+}
+
+func (index *mempoolFeeIndex) lowest() *mempoolTx {
+	if len(*index) == 0 {
+		return nil
+	}
+	return (*index)[0]
+}
@@ -0,0 +1,22 @@
+package mempool
+
+import (
+	"sync/atomic"
+)
+
+//counters updated by the mempool thread
+type MempoolMetrics struct {
+	EvictedFull    uint64 `json:"evictedFull" msgpack:"evictedFull"`       //lowest fee per byte txs removed because the mempool was full
+	EvictedExpired uint64 `json:"evictedExpired" msgpack:"evictedExpired"` //txs removed because they were pending for too many blocks
+	RejectedFull   uint64 `json:"rejectedFull" msgpack:"rejectedFull"`     //txs rejected because the fee per byte was too low for a full mempool
+	RejectedQuota  uint64 `json:"rejectedQuota" msgpack:"rejectedQuota"`   //txs rejected because the sender had too many pending txs
+}
+
+func (metrics *MempoolMetrics) Get() *MempoolMetrics {
+	return &MempoolMetrics{
+		atomic.LoadUint64(&metrics.EvictedFull),
+		atomic.LoadUint64(&metrics.EvictedExpired),
+		atomic.LoadUint64(&metrics.RejectedFull),
+		atomic.LoadUint64(&metrics.RejectedQuota),
+	}
+}
@@ -0,0 +1,87 @@
+package mempool
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/config"
+	"sort"
+	"sync/atomic"
+)
+
+//the simple txs with a nonce greater than the account nonce wait in the queue until the missing nonces are included
+
+func getTxSimpleVin(tx *mempoolTx) *transaction_simple.TransactionSimple {
+	if tx.Tx.Version == transaction_type.TX_SIMPLE {
+		if base := tx.Tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple); base.HasVin() {
+			return base
+		}
+	}
+	return nil
+}
+
+//it returns true when the tx has to wait for the missing nonces
+func isFutureNonce(tx *mempoolTx, dataStorage *data_storage.DataStorage) (bool, error) {
+
+	base := getTxSimpleVin(tx)
+	if base == nil {
+		return false, nil
+	}
+
+	plainAcc, err := dataStorage.PlainAccs.Get(string(base.Vin.PublicKey))
+	if err != nil || plainAcc == nil || base.Nonce <= plainAcc.Nonce {
+		return false, err
+	}
+
+	if base.Nonce-plainAcc.Nonce > config.MEMPOOL_MAX_ACCOUNT_TXS {
+		return false, errors.New("Transaction nonce is too far in the future")
+	}
+
+	return true, nil
+}
+
+func (self *MempoolTxs) queueTx(tx *mempoolTx) {
+	if _, loaded := self.queuedTxsMap.LoadOrStore(tx.Tx.Bloom.HashStr, tx); !loaded {
+		atomic.AddInt32(&self.queuedCount, 1)
+		atomic.AddUint64(&self.queuedSize, tx.Tx.Bloom.Size)
+	}
+}
+
+func (self *MempoolTxs) dequeueTx(hashStr string) {
+	if tx, deleted := self.queuedTxsMap.LoadAndDelete(hashStr); deleted {
+		atomic.AddInt32(&self.queuedCount, -1)
+		atomic.AddUint64(&self.queuedSize, ^(tx.Tx.Bloom.Size - 1))
+	}
+}
+
+func (self *MempoolTxs) ExistsQueued(txId string) bool {
+	_, loaded := self.queuedTxsMap.Load(txId)
+	return loaded
+}
+
+func (self *MempoolTxs) GetAccountQueuedTxs(publicKey []byte) (out []*mempoolTx) {
+	self.queuedTxsMap.Range(func(key string, tx *mempoolTx) bool {
+		if base := getTxSimpleVin(tx); base != nil && bytes.Equal(base.Vin.PublicKey, publicKey) {
+			out = append(out, tx)
+		}
+		return true
+	})
+	return
+}
+
+func (mempool *Mempool) GetQueuedNonces(publicKey []byte) []uint64 {
+
+	txs := mempool.Txs.GetAccountQueuedTxs(publicKey)
+
+	nonces := make([]uint64, len(txs))
+	for i, tx := range txs {
+		nonces[i] = getTxSimpleVin(tx).Nonce
+	}
+	sort.Slice(nonces, func(i, j int) bool {
+		return nonces[i] < nonces[j]
+	})
+
+	return nonces
+}
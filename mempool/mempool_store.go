@@ -0,0 +1,136 @@
+package mempool
+
+import (
+	"context"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/gui"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/recovery"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+//the accepted txs are stored, so they are not lost when the node restarts
+
+//changes written in the same update
+const mempoolStoreBatchSize = 1000
+
+//a nil data removes the tx. An empty hash only notifies the done channel
+type mempoolStoreChange struct {
+	hash string
+	data []byte
+	done chan struct{}
+}
+
+//the worker only queues the changes. They are written in batches by a separate goroutine, so the worker doesn't wait for the store
+type mempoolStore struct {
+	db        store_db_interface.StoreDBInterface
+	changesCn chan *mempoolStoreChange
+}
+
+func (mempoolStore *mempoolStore) storeTx(tx *mempoolTx) {
+	mempoolStore.changesCn <- &mempoolStoreChange{tx.Tx.Bloom.HashStr, tx.Tx.Bloom.Serialized, nil}
+}
+
+func (mempoolStore *mempoolStore) removeTxs(hashes []string) {
+	for _, hash := range hashes {
+		mempoolStore.changesCn <- &mempoolStoreChange{hash, nil, nil}
+	}
+}
+
+//it returns after the previous changes were written
+func (mempoolStore *mempoolStore) flush() {
+	done := make(chan struct{})
+	mempoolStore.changesCn <- &mempoolStoreChange{"", nil, done}
+	<-done
+}
+
+func (mempoolStore *mempoolStore) writeChanges(changes []*mempoolStoreChange) {
+	if err := mempoolStore.db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		for _, change := range changes {
+			if change.hash == "" {
+				continue
+			}
+			if change.data != nil {
+				writer.Put("mempool:tx:"+change.hash, change.data)
+			} else {
+				writer.Delete("mempool:tx:" + change.hash)
+			}
+		}
+		return nil
+	}); err != nil {
+		gui.GUI.Error("Error storing mempool txs", err)
+	}
+	for _, change := range changes {
+		if change.done != nil {
+			close(change.done)
+		}
+	}
+}
+
+func (mempoolStore *mempoolStore) processing() {
+	for {
+		changes := []*mempoolStoreChange{<-mempoolStore.changesCn}
+
+		//the changes queued meanwhile are written in the same update
+		for len(changes) < mempoolStoreBatchSize && len(mempoolStore.changesCn) > 0 {
+			changes = append(changes, <-mempoolStore.changesCn)
+		}
+
+		mempoolStore.writeChanges(changes)
+	}
+}
+
+func createMempoolStore() *mempoolStore {
+	mempoolStore := &mempoolStore{
+		store.StoreMempool.DB,
+		make(chan *mempoolStoreChange, mempoolStoreBatchSize),
+	}
+	recovery.SafeGo(mempoolStore.processing)
+	return mempoolStore
+}
+
+//the stored txs are validated again against the current chain and broadcasted
+func (mempool *Mempool) LoadStoredTxs(height uint64) (err error) {
+
+	txs := make([]*transaction.Transaction, 0)
+	invalid := make([]string, 0)
+
+	if err = mempool.store.db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		return reader.Iterate("mempool:tx:", "", false, func(key string, value []byte) (bool, error) {
+			tx := &transaction.Transaction{}
+			if err := tx.Deserialize(advanced_buffers.NewBufferReader(value)); err != nil {
+				invalid = append(invalid, key[len("mempool:tx:"):])
+				return true, nil
+			}
+			txs = append(txs, tx)
+			return true, nil
+		})
+	}); err != nil {
+		return
+	}
+
+	mempool.store.removeTxs(invalid)
+
+	if len(txs) == 0 {
+		return
+	}
+
+	errs := mempool.AddTxsToMempool(txs, height, false, true, false, advanced_connection_types.UUID_ALL, context.Background())
+
+	count := 0
+	for i, err := range errs {
+		if err != nil {
+			invalid = append(invalid, txs[i].Bloom.HashStr)
+		} else {
+			count += 1
+		}
+	}
+	mempool.store.removeTxs(invalid)
+
+	gui.GUI.Info("Mempool restored " + strconv.Itoa(count) + " txs out of " + strconv.Itoa(len(txs)))
+
+	return
+}
@@ -0,0 +1,355 @@
+package mempool
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether"
+	"pandora-pay/blockchain/transactions/transaction/transaction_zether/transaction_zether_payload"
+	"pandora-pay/config"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"pandora-pay/txs_validator"
+	"testing"
+	"time"
+)
+
+//the accounts are created before the mempool thread starts reading the chain
+func newTestMempool(t *testing.T, accounts int) (*Mempool, []*addresses.PrivateKey) {
+
+	var err error
+	if gui.GUI == nil {
+		gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+		assert.Nil(t, err)
+	}
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.Nil(t, err)
+	store.StoreBlockchain = &store.Store{"blockchain", true, db}
+
+	db, err = store_db_memory.CreateStoreDBMemory("mempool")
+	assert.Nil(t, err)
+	store.StoreMempool = &store.Store{"mempool", true, db}
+
+	assert.Nil(t, txs_validator.NewTxsValidator())
+
+	keys := make([]*addresses.PrivateKey, accounts)
+	for i := range keys {
+		keys[i] = addresses.GenerateNewPrivateKey()
+		createTestPlainAccount(t, keys[i].GeneratePublicKey())
+	}
+
+	mempool, err := CreateMempool()
+	assert.Nil(t, err)
+	mempool.UpdateWork(cryptography.RandomHash(), 10)
+
+	return mempool, keys
+}
+
+func createTestPlainAccount(t *testing.T, publicKey []byte) {
+	assert.Nil(t, store.StoreBlockchain.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		dataStorage := data_storage.NewDataStorage(writer)
+		plainAcc, err := dataStorage.CreatePlainAccount(publicKey, false)
+		if err != nil {
+			return err
+		}
+		if err = plainAcc.AddUnclaimed(true, config_coins.ConvertToUnitsUint64Forced(1000)); err != nil {
+			return err
+		}
+		if err = dataStorage.PlainAccs.Update(string(publicKey), plainAcc); err != nil {
+			return err
+		}
+		return dataStorage.CommitChanges()
+	}))
+}
+
+func newTestTx(t *testing.T, key *addresses.PrivateKey, nonce, fee uint64) *transaction.Transaction {
+	return newTestExpiringTx(t, key, nonce, fee, 0)
+}
+
+func newTestExpiringTx(t *testing.T, key *addresses.PrivateKey, nonce, fee, expiryHeight uint64) *transaction.Transaction {
+	tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{nil, nil, false, nil},
+		&wizard.WizardTransactionData{},
+		&wizard.WizardTransactionFee{fee, 0, 0, false},
+		nonce,
+		expiryHeight,
+		key.Key,
+	}, true, func(string) {})
+	assert.Nil(t, err)
+	return tx
+}
+
+func addTestTxs(mempool *Mempool, txs ...*transaction.Transaction) []error {
+	return mempool.AddTxsToMempool(txs, 10, false, true, false, advanced_connection_types.UUID_SKIP_ALL, context.Background())
+}
+
+func TestMempool_Bounds(t *testing.T) {
+
+	maxTxs, maxAccountTxs := config.MEMPOOL_MAX_TXS, config.MEMPOOL_MAX_ACCOUNT_TXS
+	config.MEMPOOL_MAX_TXS, config.MEMPOOL_MAX_ACCOUNT_TXS = 3, 2
+	defer func() {
+		config.MEMPOOL_MAX_TXS, config.MEMPOOL_MAX_ACCOUNT_TXS = maxTxs, maxAccountTxs
+	}()
+
+	mempool, keys := newTestMempool(t, 4)
+
+	//the quota is enforced inside the same batch
+	a1 := newTestTx(t, keys[0], 1, 10000)
+	errs := addTestTxs(mempool, newTestTx(t, keys[0], 0, 20000), a1, newTestTx(t, keys[0], 2, 20000))
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+	assert.NotNil(t, errs[2])
+	assert.Equal(t, uint64(1), mempool.Txs.Metrics.Get().RejectedQuota)
+
+	assert.Nil(t, addTestTxs(mempool, newTestTx(t, keys[1], 0, 30000))[0])
+	assert.Equal(t, int32(3), mempool.Txs.GetCount())
+
+	//the mempool is full and the fee per byte is lower than the lowest one
+	assert.NotNil(t, addTestTxs(mempool, newTestTx(t, keys[2], 0, 5000))[0])
+	assert.Equal(t, uint64(1), mempool.Txs.Metrics.Get().RejectedFull)
+
+	//the lowest fee per byte tx is evicted
+	assert.Nil(t, addTestTxs(mempool, newTestTx(t, keys[3], 0, 40000))[0])
+	assert.Equal(t, int32(3), mempool.Txs.GetCount())
+	assert.False(t, mempool.Txs.Exists(a1.Bloom.HashStr))
+	assert.Equal(t, uint64(1), mempool.Txs.Metrics.Get().EvictedFull)
+
+	//the evicted tx doesn't count for the quota anymore
+	assert.Equal(t, 1, mempool.Txs.CountSenderTxs(keys[0].GeneratePublicKey()))
+
+}
+
+func TestMempool_Queue(t *testing.T) {
+
+	maxTxs, maxAccountTxs := config.MEMPOOL_MAX_TXS, config.MEMPOOL_MAX_ACCOUNT_TXS
+	config.MEMPOOL_MAX_TXS, config.MEMPOOL_MAX_ACCOUNT_TXS = 4, 2
+	defer func() {
+		config.MEMPOOL_MAX_TXS, config.MEMPOOL_MAX_ACCOUNT_TXS = maxTxs, maxAccountTxs
+	}()
+
+	mempool, keys := newTestMempool(t, 5)
+
+	a1 := newTestTx(t, keys[0], 1, 30000)
+	errs := addTestTxs(mempool, a1, newTestTx(t, keys[0], 2, 30000))
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+	assert.Equal(t, []uint64{1, 2}, mempool.GetQueuedNonces(keys[0].GeneratePublicKey()))
+	assert.Equal(t, int32(0), mempool.Txs.GetCount())
+	assert.Equal(t, int32(2), mempool.Txs.GetQueuedCount())
+
+	//the nonce is too far in the future
+	assert.NotNil(t, addTestTxs(mempool, newTestTx(t, keys[0], 3, 30000))[0])
+
+	//the sender is over the quota, so the highest queued nonce makes room for the missing one
+	assert.Nil(t, addTestTxs(mempool, newTestTx(t, keys[0], 0, 30000))[0])
+	assert.Eventually(t, func() bool {
+		return mempool.Txs.Exists(a1.Bloom.HashStr)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, len(mempool.GetQueuedNonces(keys[0].GeneratePublicKey())))
+	assert.Equal(t, int32(2), mempool.Txs.GetCount())
+
+	assert.Nil(t, addTestTxs(mempool, newTestTx(t, keys[1], 0, 50000))[0])
+	assert.Nil(t, addTestTxs(mempool, newTestTx(t, keys[2], 1, 20000))[0])
+
+	//the queued txs are counted in the limits
+	assert.NotNil(t, addTestTxs(mempool, newTestTx(t, keys[3], 1, 10000))[0])
+	assert.Equal(t, uint64(1), mempool.Txs.Metrics.Get().RejectedFull)
+
+	//and they are evicted first when they have the lowest fee per byte
+	assert.Nil(t, addTestTxs(mempool, newTestTx(t, keys[4], 0, 60000))[0])
+	assert.Equal(t, 0, len(mempool.GetQueuedNonces(keys[2].GeneratePublicKey())))
+	assert.Equal(t, int32(0), mempool.Txs.GetQueuedCount())
+	assert.Equal(t, int32(4), mempool.Txs.GetCount())
+	assert.Equal(t, uint64(1), mempool.Txs.Metrics.Get().EvictedFull)
+
+}
+
+func TestMempool_ReplaceByFee(t *testing.T) {
+
+	mempool, keys := newTestMempool(t, 1)
+	publicKey := keys[0].GeneratePublicKey()
+
+	tx := newTestTx(t, keys[0], 0, 20000)
+	assert.Nil(t, addTestTxs(mempool, tx)[0])
+	assert.Equal(t, uint64(1), mempool.GetNonce(publicKey, 0))
+
+	//the fee per byte has to be bumped
+	assert.NotNil(t, addTestTxs(mempool, newTestTx(t, keys[0], 0, 21000))[0])
+	assert.True(t, mempool.Txs.Exists(tx.Bloom.HashStr))
+
+	replacement := newTestTx(t, keys[0], 0, 40000)
+	assert.Nil(t, addTestTxs(mempool, replacement)[0])
+	assert.False(t, mempool.Txs.Exists(tx.Bloom.HashStr))
+	assert.Equal(t, replacement.Bloom.HashStr, mempool.getTxSimpleByNonce(publicKey, 0).Tx.Bloom.HashStr)
+	assert.Equal(t, int32(1), mempool.Txs.GetCount())
+
+	//the replaced tx is restored when the replacement can't be included, even if nobody awaits the answer
+	invalid := newTestTx(t, keys[0], 0, config_coins.ConvertToUnitsUint64Forced(2000))
+	mempool.AddTxsToMempool([]*transaction.Transaction{invalid}, 10, false, false, false, advanced_connection_types.UUID_SKIP_ALL, context.Background())
+	assert.Eventually(t, func() bool {
+		return mempool.Txs.Exists(replacement.Bloom.HashStr)
+	}, time.Second, 10*time.Millisecond)
+	mempool.RemoveInsertedTxsFromBlockchain(nil) //waiting for the mempool thread to store it
+	assert.False(t, mempool.Txs.Exists(invalid.Bloom.HashStr))
+	assert.Equal(t, int32(1), mempool.Txs.GetCount())
+
+}
+
+func TestMempool_Expiry(t *testing.T) {
+
+	forks, expiryBlocks := config.NETWORK_SELECTED_FORKS, config.MEMPOOL_TX_EXPIRY_BLOCKS
+	defer func() {
+		config.NETWORK_SELECTED_FORKS, config.MEMPOOL_TX_EXPIRY_BLOCKS = forks, expiryBlocks
+	}()
+
+	mempool, keys := newTestMempool(t, 3)
+
+	//the expiry height is rejected before the activation
+	config.NETWORK_SELECTED_FORKS = &config.Forks{StateRoot: 1000, TxExpiry: 1000}
+	assert.NotNil(t, addTestTxs(mempool, newTestExpiringTx(t, keys[0], 0, 20000, 100))[0])
+
+	config.NETWORK_SELECTED_FORKS = &config.Forks{StateRoot: 1000, TxExpiry: 0}
+	config.MEMPOOL_TX_EXPIRY_BLOCKS = 5
+
+	assert.NotNil(t, addTestTxs(mempool, newTestExpiringTx(t, keys[0], 0, 20000, 9))[0])
+
+	expiring, old, fresh := newTestExpiringTx(t, keys[0], 0, 20000, 11), newTestTx(t, keys[1], 0, 20000), newTestTx(t, keys[2], 0, 20000)
+	errs := addTestTxs(mempool, expiring, old)
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+
+	//the new work removes the expired txs
+	mempool.UpdateWork(cryptography.RandomHash(), 12)
+	assert.Nil(t, addTestTxs(mempool, fresh)[0])
+	assert.False(t, mempool.Txs.Exists(expiring.Bloom.HashStr))
+	assert.True(t, mempool.Txs.Exists(old.Bloom.HashStr))
+
+	//the txs pending for too many blocks are removed as well
+	mempool.UpdateWork(cryptography.RandomHash(), 16)
+	mempool.RemoveInsertedTxsFromBlockchain(nil) //waiting for the mempool thread
+	assert.False(t, mempool.Txs.Exists(old.Bloom.HashStr))
+	assert.True(t, mempool.Txs.Exists(fresh.Bloom.HashStr))
+	assert.Equal(t, uint64(2), mempool.Txs.Metrics.Get().EvictedExpired)
+
+}
+
+func TestMempool_Notifications(t *testing.T) {
+
+	extendedInfo := config.NODE_PROVIDE_EXTENDED_INFO_APP
+	config.NODE_PROVIDE_EXTENDED_INFO_APP = true
+	defer func() {
+		config.NODE_PROVIDE_EXTENDED_INFO_APP = extendedInfo
+	}()
+
+	mempool, keys := newTestMempool(t, 1)
+
+	updatesCn := mempool.Txs.UpdateMempoolTransactions.AddListener()
+	defer mempool.Txs.UpdateMempoolTransactions.RemoveChannel(updatesCn)
+
+	getUpdate := func() *blockchain_types.MempoolTransactionUpdate {
+		select {
+		case update := <-updatesCn:
+			return update
+		case <-time.After(time.Second):
+			t.Fatal("no mempool notification")
+		}
+		return nil
+	}
+
+	tx := newTestTx(t, keys[0], 0, 20000)
+	assert.Nil(t, addTestTxs(mempool, tx)[0])
+
+	update := getUpdate()
+	assert.Equal(t, tx.Bloom.HashStr, update.Tx.Bloom.HashStr)
+	assert.True(t, update.Inserted)
+	assert.False(t, update.IncludedInBlockchainNotification)
+	assert.Equal(t, 20000/tx.Bloom.Size, update.FeePerByte)
+	assert.True(t, update.Keys[string(keys[0].GeneratePublicKey())])
+
+	//the txs included in a block are notified as included
+	assert.True(t, mempool.RemoveInsertedTxsFromBlockchain([]string{tx.Bloom.HashStr}))
+
+	update = getUpdate()
+	assert.Equal(t, tx.Bloom.HashStr, update.Tx.Bloom.HashStr)
+	assert.False(t, update.Inserted)
+	assert.True(t, update.IncludedInBlockchainNotification)
+	assert.Equal(t, 20000/tx.Bloom.Size, update.FeePerByte)
+}
+
+func getTestStoredTxs(t *testing.T) (hashes map[string]bool) {
+	hashes = make(map[string]bool)
+	assert.Nil(t, store.StoreMempool.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		return reader.Iterate("mempool:tx:", "", false, func(key string, value []byte) (bool, error) {
+			hashes[key[len("mempool:tx:"):]] = true
+			return true, nil
+		})
+	}))
+	return
+}
+
+func TestMempool_Restore(t *testing.T) {
+
+	mempool, keys := newTestMempool(t, 2)
+
+	tx, queued, removed := newTestTx(t, keys[0], 0, 20000), newTestTx(t, keys[0], 2, 20000), newTestTx(t, keys[1], 0, 20000)
+	errs := addTestTxs(mempool, tx, queued, removed)
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+	assert.Nil(t, errs[2])
+
+	mempool.RemoveInsertedTxsFromBlockchain([]string{removed.Bloom.HashStr})
+	mempool.store.flush()
+	assert.Equal(t, map[string]bool{tx.Bloom.HashStr: true, queued.Bloom.HashStr: true}, getTestStoredTxs(t))
+
+	assert.Nil(t, store.StoreMempool.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		writer.Put("mempool:tx:invalid", []byte{1, 2, 3})
+		return nil
+	}))
+
+	//the node restarts
+	mempool, err := CreateMempool()
+	assert.Nil(t, err)
+	mempool.OnBroadcastNewTransaction = func(txs []*transaction.Transaction, justCreated, awaitBroadcasting bool, exceptSocketUUID advanced_connection_types.UUID, ctx context.Context) []error {
+		return make([]error, len(txs))
+	}
+	mempool.UpdateWork(cryptography.RandomHash(), 10)
+
+	assert.Nil(t, mempool.LoadStoredTxs(10))
+	assert.True(t, mempool.Txs.Exists(tx.Bloom.HashStr))
+	assert.Equal(t, []uint64{2}, mempool.GetQueuedNonces(keys[0].GeneratePublicKey()))
+	assert.False(t, mempool.Txs.Exists(removed.Bloom.HashStr))
+
+	mempool.store.flush()
+	assert.Equal(t, map[string]bool{tx.Bloom.HashStr: true, queued.Bloom.HashStr: true}, getTestStoredTxs(t))
+
+}
+
+func TestMempool_FeeEstimateZether(t *testing.T) {
+
+	//every payload paid 10 per byte only for its own 60, 30 and 10 bytes
+	tx := &transaction.Transaction{&transaction_zether.TransactionZether{Payloads: []*transaction_zether_payload.TransactionZetherPayload{
+		{Asset: config_coins.NATIVE_ASSET_FULL, Statement: &crypto.Statement{Fee: 600}},
+		{Asset: config_coins.NATIVE_ASSET_FULL, Statement: &crypto.Statement{Fee: 300}},
+		{Asset: helpers.RandomBytes(config_coins.ASSET_LENGTH), Statement: &crypto.Statement{Fee: 50}, FeeRate: 2},
+	}}, transaction_type.TX_ZETHER, 0, 0, nil}
+
+	assert.Equal(t, uint64(10), getTxFeePerByte(tx, 100))
+	assert.Equal(t, uint64(0), getTxFeePerByte(tx, 0))
+
+	assert.Equal(t, &FeeEstimate{10, 10, 10}, computeFeeEstimate([]uint64{getTxFeePerByte(tx, 100)}, 1))
+	assert.Equal(t, &FeeEstimate{20, 20, 20}, computeFeeEstimate([]uint64{getTxFeePerByte(tx, 100)}, 20))
+}
@@ -17,7 +17,8 @@ type mempoolWork struct {
 }
 
 type mempoolWorker struct {
-	dbTx store_db_interface.StoreDBTransactionInterface
+	dbTx  store_db_interface.StoreDBTransactionInterface
+	store *mempoolStore
 }
 
 type MempoolWorkerAddTx struct {
@@ -26,8 +27,9 @@ type MempoolWorkerAddTx struct {
 }
 
 type MempoolWorkerRemoveTxs struct {
-	Txs    []string
-	Result chan<- bool
+	Txs                  []string
+	IncludedInBlockchain bool //false when the txs are replaced
+	Result               chan<- bool
 }
 
 type MempoolWorkerInsertTxs struct {
@@ -56,6 +58,168 @@ func (worker *mempoolWorker) processing(
 	includedTotalSize := uint64(0)
 	includedTxs := []*mempoolTx{}
 
+	queuedTxs := make(map[string]map[uint64]*mempoolTx) //public key -> nonce -> tx
+
+	feeIndex := &mempoolFeeIndex{}
+
+	insertTxNow := func(tx *mempoolTx) {
+		txsMap[tx.Tx.Bloom.HashStr] = tx
+		txsList = append(txsList, tx)
+		txs.insertTx(tx)
+		txs.inserted(tx)
+		feeIndex.insert(tx)
+	}
+
+	removeTxNow := func(tx *mempoolTx, txWasInserted bool, includedInBlockchainNotification bool) {
+
+		delete(txsMap, tx.Tx.Bloom.HashStr)
+		feeIndex.remove(tx)
+
+		if txWasInserted {
+			txs.deleteTx(tx.Tx.Bloom.HashStr)
+			txs.deleted(tx, txWasInserted, includedInBlockchainNotification)
+
+		}
+	}
+
+	//the txs must be already removed using removeTxNow
+	removeFromListNow := func(removedTxsMap map[string]bool) {
+
+		removedHashes := make([]string, 0, len(removedTxsMap))
+		for hash := range removedTxsMap {
+			removedHashes = append(removedHashes, hash)
+		}
+		worker.store.removeTxs(removedHashes)
+
+		newLength := 0
+		for _, tx := range txsList {
+			if !removedTxsMap[tx.Tx.Bloom.HashStr] {
+				newLength += 1
+			}
+		}
+
+		newList := make([]*mempoolTx, newLength)
+		c := 0
+		index := 0
+		for _, tx := range txsList {
+			if !removedTxsMap[tx.Tx.Bloom.HashStr] {
+				newList[c] = tx
+				c += 1
+			} else if index < listIndex && listIndex > 0 {
+				listIndex--
+				index--
+			}
+			index++
+		}
+		txsList = newList
+	}
+
+	dequeueTxNow := func(key string, nonce uint64) *mempoolTx {
+		tx := queuedTxs[key][nonce]
+		if tx != nil {
+			delete(queuedTxs[key], nonce)
+			if len(queuedTxs[key]) == 0 {
+				delete(queuedTxs, key)
+			}
+			txs.dequeueTx(tx.Tx.Bloom.HashStr)
+			feeIndex.remove(tx)
+		}
+		return tx
+	}
+
+	//only the highest fee per byte tx is kept for a nonce
+	queueTxNow := func(tx *mempoolTx) error {
+
+		base := getTxSimpleVin(tx)
+		key := string(base.Vin.PublicKey)
+
+		if old := queuedTxs[key][base.Nonce]; old != nil {
+			if old.FeePerByte >= tx.FeePerByte {
+				return errors.New("A transaction with the same nonce and a higher fee is queued")
+			}
+			dequeueTxNow(key, base.Nonce)
+			worker.store.removeTxs([]string{old.Tx.Bloom.HashStr})
+		}
+
+		if queuedTxs[key] == nil {
+			queuedTxs[key] = make(map[uint64]*mempoolTx)
+		}
+		queuedTxs[key][base.Nonce] = tx
+		txs.queueTx(tx)
+		feeIndex.insert(tx)
+
+		return nil
+	}
+
+	//the promoted tx is appended to the list and it will be processed in the current work
+	promoteQueuedTxNow := func(key string, nonce uint64) {
+		if tx := dequeueTxNow(key, nonce); tx != nil && txsMap[tx.Tx.Bloom.HashStr] == nil {
+			insertTxNow(tx)
+		}
+	}
+
+	//the queued txs are promoted when the gap was filled by a block and removed when they became stale
+	checkQueuedTxsNow := func(dataStorage *data_storage.DataStorage) {
+
+		staleHashes := []string{}
+		for key, nonces := range queuedTxs {
+
+			plainAcc, err := dataStorage.PlainAccs.Get(key)
+			if err != nil {
+				continue
+			}
+
+			accNonce := uint64(0)
+			if plainAcc != nil {
+				accNonce = plainAcc.Nonce
+			}
+
+			for nonce := range nonces {
+				if nonce < accNonce {
+					staleHashes = append(staleHashes, dequeueTxNow(key, nonce).Tx.Bloom.HashStr)
+				}
+			}
+			promoteQueuedTxNow(key, accNonce)
+		}
+
+		worker.store.removeTxs(staleHashes)
+	}
+
+	//the txs pending for too many blocks are removed
+	expireTxsNow := func(chainHeight uint64) {
+
+		isExpired := func(tx *mempoolTx) bool {
+			if config.MEMPOOL_TX_EXPIRY_BLOCKS > 0 && tx.ChainHeight+config.MEMPOOL_TX_EXPIRY_BLOCKS < chainHeight {
+				return true
+			}
+			return tx.Tx.IsExpired(chainHeight)
+		}
+
+		expiredTxsMap := make(map[string]bool)
+		for _, tx := range txsList {
+			if isExpired(tx) {
+				expiredTxsMap[tx.Tx.Bloom.HashStr] = true
+				removeTxNow(tx, true, false)
+			}
+		}
+
+		if len(expiredTxsMap) > 0 {
+			removeFromListNow(expiredTxsMap)
+		}
+
+		expiredHashes := []string{}
+		for key, nonces := range queuedTxs {
+			for nonce, tx := range nonces {
+				if isExpired(tx) {
+					expiredHashes = append(expiredHashes, dequeueTxNow(key, nonce).Tx.Bloom.HashStr)
+				}
+			}
+		}
+		worker.store.removeTxs(expiredHashes)
+
+		atomic.AddUint64(&txs.Metrics.EvictedExpired, uint64(len(expiredTxsMap)+len(expiredHashes)))
+	}
+
 	resetNow := func(newWork *mempoolWork) {
 
 		if newWork.chainHash != nil {
@@ -64,21 +228,100 @@ func (worker *mempoolWorker) processing(
 			includedTotalSize = uint64(0)
 			includedTxs = []*mempoolTx{}
 			listIndex = 0
+			expireTxsNow(newWork.chainHeight)
 			if len(txsList) > 1 {
 				sortTxs(txsList)
 			}
 		}
 	}
 
-	removeTxNow := func(tx *mempoolTx, txWasInserted bool, includedInBlockchainNotification bool) {
+	//the removed txs might have been already included in the work
+	restartWorkNow := func(removedTxsMap map[string]bool) {
+		if work == nil {
+			return
+		}
+		for _, tx := range includedTxs {
+			if removedTxsMap[tx.Tx.Bloom.HashStr] {
+				resetNow(work)
+				work.result.txs.Store(includedTxs)
+				atomic.StoreUint64(&work.result.totalSize, 0)
+				return
+			}
+		}
+	}
 
-		delete(txsMap, tx.Tx.Bloom.HashStr)
+	//the queued txs are counted in the limits as well
+	isOverLimitsNow := func(count, size uint64) bool {
+		return uint64(txs.GetCount()+txs.GetQueuedCount())+count > config.MEMPOOL_MAX_TXS || txs.GetSize()+txs.GetQueuedSize()+size > config.MEMPOOL_MAX_SIZE
+	}
 
-		if txWasInserted {
-			txs.deleteTx(tx.Tx.Bloom.HashStr)
-			txs.deleted(tx, txWasInserted, includedInBlockchainNotification)
+	isFullNow := func(tx *mempoolTx) bool {
+		if !isOverLimitsNow(1, tx.Tx.Bloom.Size) {
+			return false
+		}
+		lowest := feeIndex.lowest()
+		return lowest == nil || lowest.FeePerByte >= tx.FeePerByte
+	}
+
+	//the zether senders are hidden in the rings, so only the simple txs have quotas
+	//the queued tx with the highest nonce makes room for a lower nonce, otherwise the sender could not fill the gap
+	isOverQuotaNow := func(tx *mempoolTx) bool {
+
+		base := getTxSimpleVin(tx)
+		if base == nil {
+			return false
+		}
+
+		key := string(base.Vin.PublicKey)
+		if queuedTxs[key][base.Nonce] != nil || uint64(txs.CountSenderTxs(base.Vin.PublicKey)+len(queuedTxs[key])) < config.MEMPOOL_MAX_ACCOUNT_TXS {
+			return false
+		}
+
+		highest, found := uint64(0), false
+		for nonce := range queuedTxs[key] {
+			if !found || nonce > highest {
+				highest, found = nonce, true
+			}
+		}
+		if !found || highest < base.Nonce {
+			return true
+		}
+
+		worker.store.removeTxs([]string{dequeueTxNow(key, highest).Tx.Bloom.HashStr})
+		return false
+	}
+
+	//the lowest fee per byte txs are evicted while the mempool is over the limits, no matter if they are pending or queued
+	evictTxsNow := func() map[string]bool {
+
+		evictedTxsMap := make(map[string]bool)
+		evictedQueuedHashes := []string{}
+		for isOverLimitsNow(0, 0) {
+			lowest := feeIndex.lowest()
+			if lowest == nil {
+				break
+			}
+			if base := getTxSimpleVin(lowest); base != nil && queuedTxs[string(base.Vin.PublicKey)][base.Nonce] == lowest {
+				dequeueTxNow(string(base.Vin.PublicKey), base.Nonce)
+				evictedQueuedHashes = append(evictedQueuedHashes, lowest.Tx.Bloom.HashStr)
+			} else {
+				evictedTxsMap[lowest.Tx.Bloom.HashStr] = true
+				removeTxNow(lowest, true, false)
+			}
+		}
 
+		if len(evictedTxsMap) > 0 {
+			removeFromListNow(evictedTxsMap)
+			restartWorkNow(evictedTxsMap)
 		}
+		worker.store.removeTxs(evictedQueuedHashes)
+
+		atomic.AddUint64(&txs.Metrics.EvictedFull, uint64(len(evictedTxsMap)+len(evictedQueuedHashes)))
+		for _, hash := range evictedQueuedHashes {
+			evictedTxsMap[hash] = true
+		}
+
+		return evictedTxsMap
 	}
 
 	removeTxs := func(data *MempoolWorkerRemoveTxs) {
@@ -88,33 +331,15 @@ func (worker *mempoolWorker) processing(
 			if hash != "" {
 				if tx := txsMap[hash]; tx != nil {
 					removedTxsMap[hash] = true
-					removeTxNow(tx, true, true)
+					removeTxNow(tx, true, data.IncludedInBlockchain)
 				}
 			}
 		}
 		if len(removedTxsMap) > 0 {
-
-			newLength := 0
-			for _, tx := range txsList {
-				if !removedTxsMap[tx.Tx.Bloom.HashStr] {
-					newLength += 1
-				}
-			}
-
-			newList := make([]*mempoolTx, newLength)
-			c := 0
-			index := 0
-			for _, tx := range txsList {
-				if !removedTxsMap[tx.Tx.Bloom.HashStr] {
-					newList[c] = tx
-					c += 1
-				} else if index < listIndex && listIndex > 0 {
-					listIndex--
-					index--
-				}
-				index++
+			removeFromListNow(removedTxsMap)
+			if !data.IncludedInBlockchain {
+				restartWorkNow(removedTxsMap)
 			}
-			txsList = newList
 		}
 
 		data.Result <- len(removedTxsMap) > 0
@@ -124,13 +349,14 @@ func (worker *mempoolWorker) processing(
 		result := false
 		for _, tx := range data.Txs {
 			if tx != nil && txsMap[tx.Tx.Bloom.HashStr] == nil {
-				txsMap[tx.Tx.Bloom.HashStr] = tx
-				txs.insertTx(tx)
-				txs.inserted(tx)
-				txsList = append(txsList, tx)
+				insertTxNow(tx)
+				worker.store.storeTx(tx)
 				result = true
 			}
 		}
+		if result {
+			evictTxsNow()
+		}
 		data.Result <- result
 	}
 
@@ -180,6 +406,7 @@ func (worker *mempoolWorker) processing(
 
 				if dataStorage == nil {
 					dataStorage = data_storage.NewDataStorage(dbTx)
+					checkQueuedTxsNow(dataStorage)
 				}
 
 				tx = nil
@@ -197,7 +424,7 @@ func (worker *mempoolWorker) processing(
 					case data := <-insertTransactionsCn:
 						insertTxs(data)
 					case newAddTx = <-addTransactionCn:
-						if txsMap[newAddTx.Tx.Tx.Bloom.HashStr] != nil {
+						if txsMap[newAddTx.Tx.Tx.Bloom.HashStr] != nil || txs.ExistsQueued(newAddTx.Tx.Tx.Bloom.HashStr) {
 							if newAddTx.Result != nil {
 								newAddTx.Result <- nil //no error, already included in mempool
 							}
@@ -227,6 +454,11 @@ func (worker *mempoolWorker) processing(
 					continue
 				}
 
+				//the locally created txs don't know the chain height
+				if newAddTx != nil && tx.ChainHeight < work.chainHeight {
+					tx.ChainHeight = work.chainHeight
+				}
+
 				var finalErr error
 				var exists bool
 
@@ -234,16 +466,36 @@ func (worker *mempoolWorker) processing(
 					finalErr = errors.New("Tx is already included in blockchain")
 				}
 
+				if finalErr == nil && newAddTx != nil && isFullNow(tx) {
+					atomic.AddUint64(&txs.Metrics.RejectedFull, 1)
+					finalErr = errors.New("Mempool is full and the fee per byte is too low")
+				}
+
+				if finalErr == nil && newAddTx != nil && isOverQuotaNow(tx) {
+					atomic.AddUint64(&txs.Metrics.RejectedQuota, 1)
+					finalErr = errors.New("Sender has too many pending transactions")
+				}
+
 				if finalErr == nil {
+
+					var futureNonce bool
+
 					//was rejected by mempool nonce map
 					finalErr = func() (err error) {
 
+						included := false
+
 						defer func() {
 							if errReturned := recover(); errReturned != nil {
 								err = errReturned.(error)
 							}
 						}()
 
+						//the tx waits in the queue until the missing nonces are processed
+						if futureNonce, err = isFutureNonce(tx, dataStorage); err != nil || futureNonce {
+							return
+						}
+
 						if err = tx.Tx.IncludeTransaction(work.chainHeight, dataStorage); err != nil {
 							dataStorage.Rollback()
 							return
@@ -260,6 +512,7 @@ func (worker *mempoolWorker) processing(
 								if err = dataStorage.CommitChanges(); err != nil {
 									return
 								}
+								included = true
 
 							} else {
 								dataStorage.Rollback()
@@ -267,10 +520,16 @@ func (worker *mempoolWorker) processing(
 
 							if newAddTx != nil {
 								listIndex += 1
-								txsList = append(txsList, newAddTx.Tx)
-								txsMap[tx.Tx.Bloom.HashStr] = newAddTx.Tx
-								txs.insertTx(tx)
-								txs.inserted(tx)
+								insertTxNow(tx)
+								worker.store.storeTx(tx)
+								if evictTxsNow()[tx.Tx.Bloom.HashStr] {
+									return errors.New("Mempool is full and the fee per byte is too low")
+								}
+							}
+
+							//the next nonce can be processed now
+							if base := getTxSimpleVin(tx); included && base != nil {
+								promoteQueuedTxNow(string(base.Vin.PublicKey), base.Nonce+1)
 							}
 
 						}
@@ -284,12 +543,35 @@ func (worker *mempoolWorker) processing(
 							//this is done because it was inserted before
 							txsList = slices.Delete(txsList, listIndex-1, listIndex)
 							listIndex--
+							worker.store.removeTxs([]string{tx.Tx.Bloom.HashStr})
 						}
 						removeTxNow(tx, newAddTx == nil, exists)
+					} else if futureNonce {
+						if newAddTx == nil {
+							txsList = slices.Delete(txsList, listIndex-1, listIndex)
+							listIndex--
+							removeTxNow(tx, true, false)
+						}
+						if finalErr = queueTxNow(tx); finalErr != nil {
+							worker.store.removeTxs([]string{tx.Tx.Bloom.HashStr})
+						} else if evictTxsNow()[tx.Tx.Bloom.HashStr] {
+							finalErr = errors.New("Mempool is full and the fee per byte is too low")
+						} else if newAddTx != nil {
+							worker.store.storeTx(tx)
+						}
 					}
 
 				}
 
+				//the replaced tx was removed before and it is restored when the replacement failed
+				if newAddTx != nil && tx.replaces != nil {
+					if finalErr != nil && txsMap[tx.replaces.Tx.Bloom.HashStr] == nil {
+						insertTxNow(tx.replaces)
+						worker.store.storeTx(tx.replaces)
+					}
+					tx.replaces = nil
+				}
+
 				if newAddTx != nil && newAddTx.Result != nil {
 					newAddTx.Result <- finalErr
 				}
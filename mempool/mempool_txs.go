@@ -24,8 +24,15 @@ type MempoolAccountTxs struct {
 
 type MempoolTxs struct {
 	count                     int32
+	size                      uint64 //bytes of all txs
+	queuedCount               int32
+	queuedSize                uint64 //bytes of all queued txs
 	txsMap                    *generics.Map[string, *mempoolTx]
 	accountsMapTxs            *generics.Map[string, *MempoolAccountTxs]
+	queuedTxsMap              *generics.Map[string, *mempoolTx] //simple txs waiting for the missing nonces
+	senderTxs                 map[string]map[uint64]*mempoolTx  //pending simple txs by the sender and the nonce
+	senderTxsLock             *sync.RWMutex
+	Metrics                   *MempoolMetrics
 	UpdateMempoolTransactions *multicast.MulticastChannel[*blockchain_types.MempoolTransactionUpdate]
 }
 
@@ -33,10 +40,38 @@ func (self *MempoolTxs) insertTx(tx *mempoolTx) bool {
 	_, loaded := self.txsMap.LoadOrStore(tx.Tx.Bloom.HashStr, tx)
 	if !loaded {
 		atomic.AddInt32(&self.count, 1)
+		atomic.AddUint64(&self.size, tx.Tx.Bloom.Size)
+		self.insertSenderTx(tx)
 	}
 	return !loaded
 }
 
+func (self *MempoolTxs) insertSenderTx(tx *mempoolTx) {
+	if base := getTxSimpleVin(tx); base != nil {
+		self.senderTxsLock.Lock()
+		defer self.senderTxsLock.Unlock()
+		key := string(base.Vin.PublicKey)
+		if self.senderTxs[key] == nil {
+			self.senderTxs[key] = make(map[uint64]*mempoolTx)
+		}
+		self.senderTxs[key][base.Nonce] = tx
+	}
+}
+
+func (self *MempoolTxs) deleteSenderTx(tx *mempoolTx) {
+	if base := getTxSimpleVin(tx); base != nil {
+		self.senderTxsLock.Lock()
+		defer self.senderTxsLock.Unlock()
+		key := string(base.Vin.PublicKey)
+		if self.senderTxs[key][base.Nonce] == tx {
+			delete(self.senderTxs[key], base.Nonce)
+			if len(self.senderTxs[key]) == 0 {
+				delete(self.senderTxs, key)
+			}
+		}
+	}
+}
+
 func (self *MempoolTxs) inserted(tx *mempoolTx) {
 	if config.NODE_PROVIDE_EXTENDED_INFO_APP {
 
@@ -65,15 +100,18 @@ func (self *MempoolTxs) inserted(tx *mempoolTx) {
 			tx.Tx,
 			false,
 			keys,
+			tx.FeePerByte,
 		})
 
 	}
 }
 
 func (self *MempoolTxs) deleteTx(hashStr string) bool {
-	_, deleted := self.txsMap.LoadAndDelete(hashStr)
+	tx, deleted := self.txsMap.LoadAndDelete(hashStr)
 	if deleted {
 		atomic.AddInt32(&self.count, -1)
+		atomic.AddUint64(&self.size, ^(tx.Tx.Bloom.Size - 1))
+		self.deleteSenderTx(tx)
 	}
 	return deleted
 }
@@ -101,6 +139,7 @@ func (self *MempoolTxs) deleted(tx *mempoolTx, broadcastNotifications, includedI
 				tx.Tx,
 				includedInBlockchainNotification,
 				keys,
+				tx.FeePerByte,
 			})
 		}
 
@@ -142,6 +181,22 @@ func (self *MempoolTxs) GetTxsOnlyList() []*transaction.Transaction {
 	return out
 }
 
+func (self *MempoolTxs) GetCount() int32 {
+	return atomic.LoadInt32(&self.count)
+}
+
+func (self *MempoolTxs) GetSize() uint64 {
+	return atomic.LoadUint64(&self.size)
+}
+
+func (self *MempoolTxs) GetQueuedCount() int32 {
+	return atomic.LoadInt32(&self.queuedCount)
+}
+
+func (self *MempoolTxs) GetQueuedSize() uint64 {
+	return atomic.LoadUint64(&self.queuedSize)
+}
+
 func (self *MempoolTxs) Exists(txId string) bool {
 	_, loaded := self.txsMap.Load(txId)
 	return loaded
@@ -156,6 +211,18 @@ func (self *MempoolTxs) Get(txId string) *mempoolTx {
 	return value
 }
 
+func (self *MempoolTxs) CountSenderTxs(publicKey []byte) int {
+	self.senderTxsLock.RLock()
+	defer self.senderTxsLock.RUnlock()
+	return len(self.senderTxs[string(publicKey)])
+}
+
+func (self *MempoolTxs) GetSenderTx(publicKey []byte, nonce uint64) *mempoolTx {
+	self.senderTxsLock.RLock()
+	defer self.senderTxsLock.RUnlock()
+	return self.senderTxs[string(publicKey)][nonce]
+}
+
 func (self *MempoolTxs) GetAccountTxs(publicKey []byte) []*mempoolTx {
 
 	if config.NODE_PROVIDE_EXTENDED_INFO_APP {
@@ -182,9 +249,16 @@ func (self *MempoolTxs) GetAccountTxs(publicKey []byte) []*mempoolTx {
 func createMempoolTxs() (txs *MempoolTxs) {
 
 	txs = &MempoolTxs{
+		0,
+		0,
+		0,
 		0,
 		&generics.Map[string, *mempoolTx]{},
 		&generics.Map[string, *MempoolAccountTxs]{},
+		&generics.Map[string, *mempoolTx]{},
+		make(map[string]map[uint64]*mempoolTx),
+		&sync.RWMutex{},
+		&MempoolMetrics{},
 		multicast.NewMulticastChannel[*blockchain_types.MempoolTransactionUpdate](),
 	}
 
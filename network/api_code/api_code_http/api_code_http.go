@@ -2,27 +2,34 @@ package api_code_http
 
 import (
 	"encoding/json"
-	"io"
+	"errors"
 	"net/http"
 	"net/url"
 	"pandora-pay/helpers/urldecoder"
 	"pandora-pay/network/api_code/api_code_types"
 )
 
-func HandleAuthenticated[T any, B any](callback func(r *http.Request, args *T, reply *B, authenticated bool) error) func(values url.Values) (interface{}, error) {
-	return func(values url.Values) (interface{}, error) {
+//the method name is given at the registration
+func HandleAuthenticated[T any, B any](callback func(r *http.Request, args *T, reply *B, authenticated bool) error) func(method string) func(values url.Values) (interface{}, error) {
+	return func(method string) func(values url.Values) (interface{}, error) {
+		api_code_types.VerifyMethodPermission(method)
+		return func(values url.Values) (interface{}, error) {
 
-		authenticated := api_code_types.CheckAuthenticated(values)
-		values.Del("user")
-		values.Del("pass")
+			user := api_code_types.CheckAuthenticated(values)
+			if user != nil && !api_code_types.CheckPermission(method, user.Username, user.Role) {
+				return nil, errors.New("Permission denied")
+			}
+			values.Del("user")
+			values.Del("pass")
 
-		args := new(T)
-		if err := urldecoder.Decoder.Decode(args, values); err != nil {
-			return nil, err
-		}
+			args := new(T)
+			if err := urldecoder.Decoder.Decode(args, values); err != nil {
+				return nil, err
+			}
 
-		reply := new(B)
-		return reply, callback(nil, args, reply, authenticated)
+			reply := new(B)
+			return reply, callback(nil, args, reply, user != nil)
+		}
 	}
 }
 
@@ -38,28 +45,36 @@ func Handle[T any, B any](callback func(r *http.Request, args *T, reply *B) erro
 	}
 }
 
-func HandlePOSTAuthenticated[T any, B any](callback func(r *http.Request, args *T, reply *B, authenticated bool) error) func(values io.ReadCloser) (interface{}, error) {
-	return func(values io.ReadCloser) (interface{}, error) {
+func HandlePOSTAuthenticated[T any, B any](callback func(r *http.Request, args *T, reply *B, authenticated bool) error) func(method string) func(req *http.Request) (interface{}, error) {
+	return func(method string) func(req *http.Request) (interface{}, error) {
+		api_code_types.VerifyMethodPermission(method)
+		return func(req *http.Request) (interface{}, error) {
 
-		authenticated := new(api_code_types.APIAuthenticated[T])
-		if err := json.NewDecoder(values).Decode(authenticated); err != nil {
-			return nil, err
-		}
+			authenticated := new(api_code_types.APIAuthenticated[T])
+			if err := json.NewDecoder(req.Body).Decode(authenticated); err != nil {
+				return nil, err
+			}
 
-		reply := new(B)
-		return reply, callback(nil, authenticated.Data, reply, authenticated.CheckAuthenticated())
+			user := authenticated.CheckAuthenticated()
+			if user != nil && !api_code_types.CheckPermission(method, user.Username, user.Role) {
+				return nil, errors.New("Permission denied")
+			}
+
+			reply := new(B)
+			return reply, callback(req, authenticated.Data, reply, user != nil)
+		}
 	}
 }
 
-func HandlePOST[T any, B any](callback func(r *http.Request, args *T, reply *B) error) func(values io.ReadCloser) (interface{}, error) {
-	return func(values io.ReadCloser) (interface{}, error) {
+func HandlePOST[T any, B any](callback func(r *http.Request, args *T, reply *B) error) func(req *http.Request) (interface{}, error) {
+	return func(req *http.Request) (interface{}, error) {
 		args := new(T)
 
-		if err := json.NewDecoder(values).Decode(args); err != nil {
+		if err := json.NewDecoder(req.Body).Decode(args); err != nil {
 			return nil, err
 		}
 
 		reply := new(B)
-		return reply, callback(nil, args, reply)
+		return reply, callback(req, args, reply)
 	}
 }
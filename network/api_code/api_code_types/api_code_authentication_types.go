@@ -5,14 +5,15 @@ import (
 	"pandora-pay/network/network_config/network_config_auth"
 )
 
-func CheckAuthenticated(args url.Values) bool {
+//it returns nil when the credentials are invalid
+func CheckAuthenticated(args url.Values) *network_config_auth.ConfigAuth {
 
 	user := network_config_auth.CONFIG_AUTH_USERS_MAP[args.Get("user")]
-	if user == nil {
-		return false
+	if user == nil || user.Password != args.Get("pass") {
+		return nil
 	}
 
-	return user.Password == args.Get("pass")
+	return user
 }
 
 type APIAuthenticated[T any] struct {
@@ -21,11 +22,11 @@ type APIAuthenticated[T any] struct {
 	Data *T     `json:"req" msgpack:"req"`
 }
 
-func (authenticated *APIAuthenticated[T]) CheckAuthenticated() bool {
+func (authenticated *APIAuthenticated[T]) CheckAuthenticated() *network_config_auth.ConfigAuth {
 	user := network_config_auth.CONFIG_AUTH_USERS_MAP[authenticated.User]
-	if user == nil {
-		return false
+	if user == nil || user.Password != authenticated.Pass {
+		return nil
 	}
 
-	return user.Password == authenticated.Pass
+	return user
 }
@@ -0,0 +1,44 @@
+package api_code_types
+
+import (
+	"pandora-pay/gui"
+	"pandora-pay/network/network_config/network_config_auth"
+)
+
+//the minimum role required by the authenticated methods. The methods missing from the table are denied
+var API_METHODS_PERMISSIONS = map[string]network_config_auth.ConfigAuthRole{
+	"wallet/get-addresses":    network_config_auth.AUTH_ROLE_READ,
+	"wallet/get-balances":     network_config_auth.AUTH_ROLE_READ,
+	"wallet/decrypt-tx":       network_config_auth.AUTH_ROLE_READ,
+	"wallet/private-transfer": network_config_auth.AUTH_ROLE_SPENDER,
+	"wallet/generate-address": network_config_auth.AUTH_ROLE_ADMIN,
+	"wallet/create-address":   network_config_auth.AUTH_ROLE_ADMIN,
+	"wallet/delete-address":   network_config_auth.AUTH_ROLE_ADMIN,
+	"delegator-node/notify":   network_config_auth.AUTH_ROLE_ADMIN,
+	"network/bans":            network_config_auth.AUTH_ROLE_ADMIN,
+	"network/bans/add":        network_config_auth.AUTH_ROLE_ADMIN,
+	"network/bans/remove":     network_config_auth.AUTH_ROLE_ADMIN,
+}
+
+//the authenticated methods are registered only if they are in the table
+func VerifyMethodPermission(method string) {
+	if _, found := API_METHODS_PERMISSIONS[method]; !found {
+		panic("API method " + method + " has no permission")
+	}
+}
+
+func CheckPermission(method, username string, role network_config_auth.ConfigAuthRole) bool {
+
+	required, found := API_METHODS_PERMISSIONS[method]
+	if !found {
+		gui.GUI.Log("API method has no permission", method, username)
+		return false
+	}
+
+	if !role.Includes(required) {
+		gui.GUI.Log("API permission denied", method, username, string(role))
+		return false
+	}
+
+	return true
+}
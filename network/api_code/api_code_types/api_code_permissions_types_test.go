@@ -0,0 +1,35 @@
+package api_code_types
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/network/network_config/network_config_auth"
+	"testing"
+)
+
+func TestCheckPermission(t *testing.T) {
+
+	var err error
+	if gui.GUI == nil {
+		gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+		assert.Nil(t, err)
+	}
+
+	for method, required := range API_METHODS_PERMISSIONS {
+		assert.True(t, CheckPermission(method, "admin", network_config_auth.AUTH_ROLE_ADMIN), method)
+		assert.True(t, CheckPermission(method, "user", required), method)
+		assert.NotPanics(t, func() { VerifyMethodPermission(method) })
+	}
+
+	assert.True(t, CheckPermission("wallet/get-balances", "user", network_config_auth.AUTH_ROLE_READ))
+	assert.False(t, CheckPermission("wallet/private-transfer", "user", network_config_auth.AUTH_ROLE_READ))
+	assert.True(t, CheckPermission("wallet/private-transfer", "user", network_config_auth.AUTH_ROLE_SPENDER))
+	assert.False(t, CheckPermission("wallet/delete-address", "user", network_config_auth.AUTH_ROLE_SPENDER))
+	assert.False(t, CheckPermission("network/bans/add", "user", network_config_auth.AUTH_ROLE_READ))
+	assert.False(t, CheckPermission("network/bans/add", "user", ""))
+
+	//the methods missing from the table are denied even to the admins
+	assert.False(t, CheckPermission("wallet/unknown", "admin", network_config_auth.AUTH_ROLE_ADMIN))
+	assert.Panics(t, func() { VerifyMethodPermission("wallet/unknown") })
+}
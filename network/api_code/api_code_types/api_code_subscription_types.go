@@ -13,6 +13,8 @@ const (
 	SUBSCRIPTION_ASSET
 	SUBSCRIPTION_REGISTRATION
 	SUBSCRIPTION_TRANSACTION
+	SUBSCRIPTION_BLOCKS  //new blocks and the txs removed by reorgs. It has no key
+	SUBSCRIPTION_MEMPOOL //txs added and removed from the mempool. It has no key
 )
 
 type APISubscriptionNotification struct {
@@ -1,6 +1,7 @@
 package api_code_websockets
 
 import (
+	"errors"
 	"net/http"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/helpers/multicast"
@@ -10,15 +11,25 @@ import (
 
 var SubscriptionNotifications *multicast.MulticastChannel[*api_code_types.APISubscriptionNotification]
 
-func HandleAuthenticated[T any, B any](callback func(r *http.Request, args *T, reply *B, authenticated bool) error) func(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
-	return func(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
-		args := new(T)
-		if err := msgpack.Unmarshal(values, args); err != nil {
-			return nil, err
-		}
+//the method name is given at the registration
+func HandleAuthenticated[T any, B any](callback func(r *http.Request, args *T, reply *B, authenticated bool) error) func(method string) func(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
+	return func(method string) func(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
+		api_code_types.VerifyMethodPermission(method)
+		return func(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
 
-		reply := new(B)
-		return reply, callback(nil, args, reply, conn.Authenticated.IsSet())
+			authenticated := conn.IsAuthenticated()
+			if authenticated && !api_code_types.CheckPermission(method, conn.AuthenticatedUser.Load(), conn.AuthenticatedRole.Load()) {
+				return nil, errors.New("Permission denied")
+			}
+
+			args := new(T)
+			if err := msgpack.Unmarshal(values, args); err != nil {
+				return nil, err
+			}
+
+			reply := new(B)
+			return reply, callback(nil, args, reply, authenticated)
+		}
 	}
 }
 
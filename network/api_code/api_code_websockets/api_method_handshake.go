@@ -3,9 +3,27 @@ package api_code_websockets
 import (
 	"pandora-pay/config"
 	"pandora-pay/network/network_config"
+	"pandora-pay/network/node_identity"
 	"pandora-pay/network/websocks/connection"
 )
 
+//values is the challenge and the key of the verifier that must be signed with the node key. It is signed only in a permissioned network, together with the TLS session of the connection
 func Handshake(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
-	return &connection.ConnectionHandshake{config.NAME, config.VERSION_STRING, config.NETWORK_SELECTED, config.NODE_CONSENSUS, network_config.NETWORK_WEBSOCKET_ADDRESS_URL_STRING}, nil
+
+	handshake := &connection.ConnectionHandshake{config.NAME, config.VERSION_STRING, config.NETWORK_SELECTED, config.NODE_CONSENSUS, network_config.NETWORK_WEBSOCKET_ADDRESS_URL_STRING, nil, nil}
+
+	if network_config.NETWORK_PERMISSIONED && len(values) > 0 {
+		channelBinding, err := conn.Conn.GetChannelBinding()
+		if err != nil {
+			return nil, err
+		}
+		signature, err := node_identity.NodeIdentity.SignHandshake(values, channelBinding, conn.ConnectionType)
+		if err != nil {
+			return nil, err
+		}
+		handshake.NodePublicKey = node_identity.NodeIdentity.PublicKey
+		handshake.NodeSignature = signature
+	}
+
+	return handshake, nil
 }
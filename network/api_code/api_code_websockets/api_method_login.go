@@ -4,6 +4,7 @@ import (
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/network/network_config/network_config_auth"
 	"pandora-pay/network/websocks/connection"
+	"time"
 )
 
 type APILogin struct {
@@ -27,7 +28,7 @@ func Login(conn *connection.AdvancedConnection, values []byte) (interface{}, err
 		return reply, nil
 	}
 
-	conn.Authenticated.Set()
+	conn.Login(user.Username, user.Role, time.Time{})
 	reply.Status = true
 
 	return reply, nil
@@ -0,0 +1,79 @@
+package api_code_websockets
+
+import (
+	"errors"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/network/network_config/network_config_auth"
+	"pandora-pay/network/node_identity"
+	"pandora-pay/network/websocks/connection"
+	"time"
+)
+
+type APILoginChallengeReply struct {
+	Challenge     []byte `json:"challenge" msgpack:"challenge"`
+	NodePublicKey []byte `json:"nodePublicKey" msgpack:"nodePublicKey"`
+}
+
+type APILoginKey struct {
+	PublicKey []byte `json:"publicKey" msgpack:"publicKey"`
+	Signature []byte `json:"signature" msgpack:"signature"` //signature of SHA3("apiLogin" + node public key + challenge)
+}
+
+type APILoginKeyReply struct {
+	Status     bool  `json:"status" msgpack:"status"`
+	Expiration int64 `json:"expiration,omitempty" msgpack:"expiration,omitempty"` //unix time
+}
+
+//the node public key binds the signature to this node, so it can't be relayed to another node
+func GetLoginKeyMessage(challenge, nodePublicKey []byte) []byte {
+	message := append([]byte("apiLogin"), nodePublicKey...)
+	return cryptography.SHA3(append(message, challenge...))
+}
+
+//a new challenge replaces the previous one
+func LoginChallenge(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
+
+	if len(network_config_auth.CONFIG_AUTH_KEYS_MAP) == 0 {
+		return nil, errors.New("Key login is not enabled")
+	}
+	if len(node_identity.NodeIdentity.PublicKey) == 0 {
+		return nil, errors.New("Node identity is not initialized")
+	}
+
+	challenge := cryptography.RandomHash()
+	conn.LoginChallenge.Store(challenge)
+
+	return &APILoginChallengeReply{challenge, node_identity.NodeIdentity.PublicKey}, nil
+}
+
+//the challenge can be used only once
+func LoginKey(conn *connection.AdvancedConnection, values []byte) (interface{}, error) {
+	args := &APILoginKey{}
+	if err := msgpack.Unmarshal(values, args); err != nil {
+		return nil, err
+	}
+	reply := &APILoginKeyReply{}
+
+	challenge := conn.LoginChallenge.Swap([]byte{})
+	if len(challenge) == 0 {
+		return nil, errors.New("Login challenge was not requested")
+	}
+
+	user := network_config_auth.CONFIG_AUTH_KEYS_MAP[string(args.PublicKey)]
+	if user == nil || len(args.Signature) != cryptography.SignatureSize {
+		return reply, nil
+	}
+	if !crypto.VerifySignature(GetLoginKeyMessage(challenge, node_identity.NodeIdentity.PublicKey), args.Signature, args.PublicKey) {
+		return reply, nil
+	}
+
+	expiration := time.Now().Add(network_config_auth.CONFIG_AUTH_SESSION_EXPIRY)
+	conn.Login(user.Username, user.Role, expiration)
+
+	reply.Status = true
+	reply.Expiration = expiration.Unix()
+
+	return reply, nil
+}
@@ -0,0 +1,88 @@
+package api_code_websockets
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/network/network_config/network_config_auth"
+	"pandora-pay/network/node_identity"
+	"pandora-pay/network/websocks/connection"
+	"testing"
+	"time"
+)
+
+func newTestLoginKeyConnection(t *testing.T) *connection.AdvancedConnection {
+	conn, err := connection.NewAdvancedConnection(nil, "", nil, nil, false, nil, nil, nil, nil)
+	assert.Nil(t, err)
+	return conn
+}
+
+func loginTestKey(t *testing.T, conn *connection.AdvancedConnection, key *addresses.PrivateKey, nodePublicKey []byte) bool {
+
+	out, err := LoginChallenge(conn, nil)
+	assert.Nil(t, err)
+	challenge := out.(*APILoginChallengeReply)
+	assert.Equal(t, node_identity.NodeIdentity.PublicKey, challenge.NodePublicKey)
+
+	signature, err := key.Sign(GetLoginKeyMessage(challenge.Challenge, nodePublicKey))
+	assert.Nil(t, err)
+
+	out, err = LoginKey(conn, msgpackTestBytes(t, &APILoginKey{key.GeneratePublicKey(), signature}))
+	assert.Nil(t, err)
+	return out.(*APILoginKeyReply).Status
+}
+
+func msgpackTestBytes(t *testing.T, data any) []byte {
+	out, err := msgpack.Marshal(data)
+	assert.Nil(t, err)
+	return out
+}
+
+func TestLoginKey(t *testing.T) {
+
+	key, other := addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()
+	nodePublicKey := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	keysMap, identityPublicKey, expiry := network_config_auth.CONFIG_AUTH_KEYS_MAP, node_identity.NodeIdentity.PublicKey, network_config_auth.CONFIG_AUTH_SESSION_EXPIRY
+	defer func() {
+		network_config_auth.CONFIG_AUTH_KEYS_MAP, node_identity.NodeIdentity.PublicKey, network_config_auth.CONFIG_AUTH_SESSION_EXPIRY = keysMap, identityPublicKey, expiry
+	}()
+
+	conn := newTestLoginKeyConnection(t)
+
+	network_config_auth.CONFIG_AUTH_KEYS_MAP = map[string]*network_config_auth.ConfigAuthKey{}
+	_, err := LoginChallenge(conn, nil)
+	assert.NotNil(t, err)
+
+	network_config_auth.CONFIG_AUTH_KEYS_MAP[string(key.GeneratePublicKey())] = &network_config_auth.ConfigAuthKey{"user", "", network_config_auth.AUTH_ROLE_SPENDER}
+	_, err = LoginChallenge(conn, nil)
+	assert.NotNil(t, err)
+
+	node_identity.NodeIdentity.PublicKey = nodePublicKey
+
+	//the challenge has to be requested before
+	_, err = LoginKey(conn, msgpackTestBytes(t, &APILoginKey{key.GeneratePublicKey(), nil}))
+	assert.NotNil(t, err)
+
+	//a signature for a different node is rejected
+	assert.False(t, loginTestKey(t, conn, key, addresses.GenerateNewPrivateKey().GeneratePublicKey()))
+	assert.False(t, conn.IsAuthenticated())
+
+	//the keys that are not configured are rejected
+	assert.False(t, loginTestKey(t, conn, other, nodePublicKey))
+	assert.False(t, conn.IsAuthenticated())
+
+	assert.True(t, loginTestKey(t, conn, key, nodePublicKey))
+	assert.True(t, conn.IsAuthenticated())
+	assert.Equal(t, "user", conn.AuthenticatedUser.Load())
+	assert.Equal(t, network_config_auth.AUTH_ROLE_SPENDER, conn.AuthenticatedRole.Load())
+
+	//the challenge can be used only once
+	_, err = LoginKey(conn, msgpackTestBytes(t, &APILoginKey{key.GeneratePublicKey(), nil}))
+	assert.NotNil(t, err)
+
+	//the expired sessions are logged out
+	network_config_auth.CONFIG_AUTH_SESSION_EXPIRY = -time.Second
+	assert.True(t, loginTestKey(t, conn, key, nodePublicKey))
+	assert.False(t, conn.IsAuthenticated())
+}
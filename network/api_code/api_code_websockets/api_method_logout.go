@@ -12,11 +12,11 @@ func Logout(conn *connection.AdvancedConnection, values []byte) (interface{}, er
 
 	reply := &APILogoutReply{}
 
-	if conn.Authenticated.IsNotSet() {
+	if !conn.IsAuthenticated() {
 		return reply, nil
 	}
 
-	conn.Authenticated.UnSet()
+	conn.Logout()
 	reply.Status = true
 
 	return reply, nil
@@ -1,6 +1,7 @@
 package api_common
 
 import (
+	"encoding/binary"
 	"net/http"
 	"pandora-pay/blockchain/data_storage/accounts"
 	"pandora-pay/blockchain/data_storage/accounts/account"
@@ -12,6 +13,7 @@ import (
 	"pandora-pay/network/api_code/api_code_types"
 	"pandora-pay/network/api_implementation/api_common/api_types"
 	"pandora-pay/store"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -30,6 +32,12 @@ type APIAccountReply struct {
 	Reg                *registration.Registration                              `json:"registration,omitempty" msgpack:"registration,omitempty"`
 	RegSerialized      []byte                                                  `json:"registrationSerialized,omitempty" msgpack:"registrationSerialized,omitempty"`
 	RegExtra           *api_types.APISubscriptionNotificationRegistrationExtra `json:"registrationExtra,omitempty" msgpack:"registrationExtra,omitempty"`
+	StateRoot          []byte                                                  `json:"stateRoot" msgpack:"stateRoot"`
+	StateBlockHash     []byte                                                  `json:"stateBlockHash" msgpack:"stateBlockHash"` //the last block which commits the StateRoot
+	StateBlockHeight   uint64                                                  `json:"stateBlockHeight" msgpack:"stateBlockHeight"`
+	AccsProofs         []*state_tree.StateProof                                `json:"accountsProofs" msgpack:"accountsProofs"`
+	PlainAccProof      *state_tree.StateProof                                  `json:"plainAccountProof" msgpack:"plainAccountProof"` //proof of absence when there is no plain account
+	RegProof           *state_tree.StateProof                                  `json:"registrationProof" msgpack:"registrationProof"` //proof of absence when there is no registration
 }
 
 func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply *APIAccountReply) (err error) {
@@ -44,6 +52,13 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 		accsCollection := accounts.NewAccountsCollection(reader)
 		plainAccs := plain_accounts.NewPlainAccounts(reader)
 		regs := registrations.NewRegistrations(reader)
+		stateTree := state_tree.NewStateTree(reader)
+
+		reply.StateRoot = stateTree.GetRoot()
+		reply.StateBlockHash = helpers.CloneBytes(reader.Get("chainHash"))
+		if chainHeight, _ := binary.Uvarint(reader.Get("chainHeight")); chainHeight > 0 {
+			reply.StateBlockHeight = chainHeight - 1
+		}
 
 		assetsList, err := accsCollection.GetAccountAssets(publicKey)
 		if err != nil {
@@ -52,6 +67,7 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 
 		reply.Accs = make([]*account.Account, len(assetsList))
 		reply.AccsExtra = make([]*api_types.APISubscriptionNotificationAccountExtra, len(assetsList))
+		reply.AccsProofs = make([]*state_tree.StateProof, len(assetsList))
 
 		for i, assetId := range assetsList {
 
@@ -66,6 +82,7 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 			}
 
 			reply.Accs[i] = acc
+			reply.AccsProofs[i] = stateTree.GetProof(accs.GetName(), publicKey)
 			if acc != nil {
 				reply.AccsExtra[i] = &api_types.APISubscriptionNotificationAccountExtra{
 					assetId,
@@ -77,6 +94,7 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 		if reply.PlainAcc, err = plainAccs.Get(string(publicKey)); err != nil {
 			return
 		}
+		reply.PlainAccProof = stateTree.GetProof(plainAccs.GetName(), publicKey)
 		if reply.PlainAcc != nil {
 			reply.PlainAccExtra = &api_types.APISubscriptionNotificationPlainAccExtra{
 				reply.PlainAcc.Index,
@@ -86,6 +104,7 @@ func (api *APICommon) GetAccount(r *http.Request, args *APIAccountRequest, reply
 		if reply.Reg, err = regs.Get(string(publicKey)); err != nil {
 			return
 		}
+		reply.RegProof = stateTree.GetProof(regs.GetName(), publicKey)
 		if reply.Reg != nil {
 			reply.RegExtra = &api_types.APISubscriptionNotificationRegistrationExtra{
 				reply.Reg.Index,
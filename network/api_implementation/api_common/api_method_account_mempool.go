@@ -10,7 +10,8 @@ type APIAccountMempoolRequest struct {
 }
 
 type APIAccountMempoolReply struct {
-	List [][]byte `json:"list" msgpack:"list"`
+	List   [][]byte `json:"list" msgpack:"list"`
+	Queued [][]byte `json:"queued" msgpack:"queued"` //simple txs waiting for the missing nonces
 }
 
 func (api *APICommon) GetAccountMempool(r *http.Request, args *APIAccountMempoolRequest, reply *APIAccountMempoolReply) error {
@@ -31,5 +32,11 @@ func (api *APICommon) GetAccountMempool(r *http.Request, args *APIAccountMempool
 		}
 	}
 
+	queued := api.mempool.Txs.GetAccountQueuedTxs(publicKey)
+	reply.Queued = make([][]byte, len(queued))
+	for i, tx := range queued {
+		reply.Queued[i] = tx.Tx.Bloom.Hash
+	}
+
 	return nil
 }
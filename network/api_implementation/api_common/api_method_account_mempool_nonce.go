@@ -10,7 +10,8 @@ type APIAccountMempoolNonceRequest struct {
 }
 
 type APIAccountMempoolNonceReply struct {
-	Nonce uint64 `json:"nonce" msgpack:"nonce"`
+	Nonce  uint64   `json:"nonce" msgpack:"nonce"`
+	Queued []uint64 `json:"queued" msgpack:"queued"` //nonces waiting for the missing ones
 }
 
 func (api *APICommon) GetAccountMempoolNonce(r *http.Request, args *APIAccountMempoolNonceRequest, reply *APIAccountMempoolNonceReply) error {
@@ -38,5 +39,6 @@ func (api *APICommon) GetAccountMempoolNonce(r *http.Request, args *APIAccountMe
 	//}
 
 	reply.Nonce = api.mempool.GetNonce(publicKey, reply.Nonce)
+	reply.Queued = api.mempool.GetQueuedNonces(publicKey)
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"pandora-pay/config"
+	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/network/api_implementation/api_common/api_types"
 	"pandora-pay/store"
@@ -52,17 +53,26 @@ func (api *APICommon) GetAccountTxs(r *http.Request, args *APIAccountTxsRequest,
 		}
 		n := generics.Min(s+config.API_ACCOUNT_MAX_TXS, reply.Count)
 
-		reply.Txs = make([][]byte, n-s)
-		for i := 0; i < len(reply.Txs); i++ {
-			hash := reader.Get("addrTx:" + publicKeyStr + ":" + strconv.FormatUint(s+uint64(i), 10))
-			if hash == nil {
-				return errors.New("Error reading address transaction")
-			}
-			if args.Dsc {
-				reply.Txs[len(reply.Txs)-i-1] = hash
-			} else {
-				reply.Txs[i] = hash
-			}
+		if n == s {
+			return
+		}
+
+		start := s
+		if args.Dsc {
+			start = n - 1
+		}
+
+		reply.Txs = make([][]byte, 0, n-s)
+		if err = reader.Iterate("addrTx:"+publicKeyStr+":", helpers.FormatIndexKey(start), args.Dsc, func(key string, hash []byte) (bool, error) {
+			reply.Txs = append(reply.Txs, hash)
+			return uint64(len(reply.Txs)) < n-s, nil
+		}); err != nil {
+			return
+		}
+
+		//the pruned transactions are missing
+		if uint64(len(reply.Txs)) != n-s || !reader.Exists("addrTx:"+publicKeyStr+":"+helpers.FormatIndexKey(s)) {
+			return errors.New("Transactions were pruned")
 		}
 
 		return
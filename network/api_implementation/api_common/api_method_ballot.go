@@ -0,0 +1,36 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/blockchain/data_storage/ballots"
+	"pandora-pay/blockchain/data_storage/ballots/ballot"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api_code/api_code_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIBallotRequest struct {
+	Hash       helpers.Base64               `json:"hash,omitempty" msgpack:"hash,omitempty"`
+	ReturnType api_code_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+}
+
+type APIBallotReply struct {
+	Ballot     *ballot.Ballot `json:"ballot,omitempty" msgpack:"ballot,omitempty"`
+	Serialized []byte         `json:"serialized,omitempty" msgpack:"serialized,omitempty"`
+}
+
+func (api *APICommon) GetBallot(r *http.Request, args *APIBallotRequest, reply *APIBallotReply) (err error) {
+	if err := store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		reply.Ballot, err = ballots.NewBallots(reader).Get(string(args.Hash))
+		return
+	}); err != nil || reply.Ballot == nil {
+		return helpers.ReturnErrorIfNot(err, "Ballot was not found")
+	}
+
+	if args.ReturnType == api_code_types.RETURN_SERIALIZED {
+		reply.Serialized = helpers.SerializeToBytes(reply.Ballot)
+		reply.Ballot = nil
+	}
+	return
+}
@@ -37,6 +37,11 @@ func (api *APICommon) GetBlock(r *http.Request, args *APIBlockRequest, reply *AP
 			return helpers.ReturnErrorIfNot(err, "Block was not found")
 		}
 
+		//only the header is available for the pruned blocks
+		if api.ApiStore.checkBlockPruned(reader, reply.Block.Height) != nil {
+			return
+		}
+
 		txHashes := [][]byte{}
 		data := reader.Get("blockTxs" + strconv.FormatUint(reply.Block.Height, 10))
 		if err = msgpack.Unmarshal(data, &txHashes); err != nil {
@@ -38,6 +38,10 @@ func (api *APICommon) GetBlockComplete(r *http.Request, args *APIBlockCompleteRe
 			return helpers.ReturnErrorIfNot(err, "Block was not found")
 		}
 
+		if err = api.ApiStore.checkBlockPruned(reader, reply.BlockComplete.Block.Height); err != nil {
+			return
+		}
+
 		data := reader.Get("blockTxs" + strconv.FormatUint(reply.BlockComplete.Block.Height, 10))
 		if data == nil {
 			return errors.New("Strange. blockTxs was not found")
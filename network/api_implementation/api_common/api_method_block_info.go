@@ -8,6 +8,7 @@ import (
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/store"
 	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
 )
 
 type APIBlockInfoRequest struct {
@@ -26,6 +27,15 @@ func (api *APICommon) GetBlockInfo(r *http.Request, args *APIBlockInfoRequest, r
 
 		data := reader.Get("blockInfo_ByHash" + string(args.Hash))
 		if data == nil {
+			if heightStr := reader.Get("blockHeight_ByHash" + string(args.Hash)); heightStr != nil {
+				var height uint64
+				if height, err = strconv.ParseUint(string(heightStr), 10, 64); err != nil {
+					return
+				}
+				if err = api.ApiStore.checkBlockPruned(reader, height); err != nil {
+					return
+				}
+			}
 			return errors.New("BlockInfo was not found")
 		}
 		return msgpack.Unmarshal(data, reply)
@@ -0,0 +1,30 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/blockchain/data_storage/notarizations"
+	"pandora-pay/blockchain/data_storage/notarizations/notarization"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIDocumentProofRequest struct {
+	DocumentHash helpers.Base64 `json:"documentHash,omitempty" msgpack:"documentHash,omitempty"`
+}
+
+type APIDocumentProofReply struct {
+	Notarization *notarization.Notarization `json:"notarization" msgpack:"notarization"`
+	APITxProofReply
+}
+
+func (api *APICommon) GetDocumentProof(r *http.Request, args *APIDocumentProofRequest, reply *APIDocumentProofReply) error {
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if reply.Notarization, err = notarizations.NewNotarizations(reader).Get(string(args.DocumentHash)); err != nil || reply.Notarization == nil {
+			return helpers.ReturnErrorIfNot(err, "Document was not notarized")
+		}
+
+		return api.loadTxProof(reader, reply.Notarization.TxHash, reply.Notarization.BlockHeight, &reply.APITxProofReply)
+	})
+}
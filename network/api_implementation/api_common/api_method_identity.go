@@ -0,0 +1,85 @@
+package api_common
+
+import (
+	"bytes"
+	"net/http"
+	"pandora-pay/blockchain/data_storage/identities"
+	"pandora-pay/blockchain/data_storage/identities/identity"
+	"pandora-pay/helpers"
+	"pandora-pay/network/api_code/api_code_types"
+	"pandora-pay/network/api_implementation/api_common/api_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIIdentityRequest struct {
+	api_types.APIAccountBaseRequest
+	ReturnType api_code_types.APIReturnType `json:"returnType,omitempty" msgpack:"returnType,omitempty"`
+}
+
+type APIIdentityReply struct {
+	Identity   *identity.Identity `json:"identity,omitempty" msgpack:"identity,omitempty"`
+	Serialized []byte             `json:"serialized,omitempty" msgpack:"serialized,omitempty"`
+}
+
+type APIIdentityAttestationsRequest struct {
+	api_types.APIAccountBaseRequest
+	Issuer     helpers.Base64 `json:"issuer,omitempty" msgpack:"issuer,omitempty"`
+	OnlyActive bool           `json:"onlyActive,omitempty" msgpack:"onlyActive,omitempty"`
+}
+
+type APIIdentityAttestationsReply struct {
+	Attestations []*identity.IdentityAttestation `json:"attestations" msgpack:"attestations"`
+}
+
+func (api *APICommon) GetIdentity(r *http.Request, args *APIIdentityRequest, reply *APIIdentityReply) (err error) {
+
+	publicKey, err := args.GetPublicKey(true)
+	if err != nil {
+		return
+	}
+
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		reply.Identity, err = identities.NewIdentities(reader).Get(string(publicKey))
+		return
+	}); err != nil || reply.Identity == nil {
+		return helpers.ReturnErrorIfNot(err, "Identity was not found")
+	}
+
+	if args.ReturnType == api_code_types.RETURN_SERIALIZED {
+		reply.Serialized = helpers.SerializeToBytes(reply.Identity)
+		reply.Identity = nil
+	}
+	return
+}
+
+func (api *APICommon) GetIdentityAttestations(r *http.Request, args *APIIdentityAttestationsRequest, reply *APIIdentityAttestationsReply) (err error) {
+
+	publicKey, err := args.GetPublicKey(true)
+	if err != nil {
+		return
+	}
+
+	var id *identity.Identity
+	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		id, err = identities.NewIdentities(reader).Get(string(publicKey))
+		return
+	}); err != nil || id == nil {
+		return helpers.ReturnErrorIfNot(err, "Identity was not found")
+	}
+
+	chainHeight := api.chain.GetChainData().Height
+
+	reply.Attestations = make([]*identity.IdentityAttestation, 0)
+	for _, attestation := range id.Attestations {
+		if len(args.Issuer) > 0 && !bytes.Equal(attestation.Issuer, args.Issuer) {
+			continue
+		}
+		if args.OnlyActive && !attestation.IsActive(chainHeight) {
+			continue
+		}
+		reply.Attestations = append(reply.Attestations, attestation)
+	}
+
+	return
+}
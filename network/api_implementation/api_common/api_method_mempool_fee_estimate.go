@@ -0,0 +1,23 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/mempool"
+)
+
+type APIMempoolFeeEstimateReply struct {
+	Simple *mempool.FeeEstimate `json:"simple" msgpack:"simple"`
+	Zether *mempool.FeeEstimate `json:"zether" msgpack:"zether"`
+}
+
+func (api *APICommon) GetMempoolFeeEstimate(r *http.Request, args *struct{}, reply *APIMempoolFeeEstimateReply) error {
+
+	estimate, err := api.mempool.EstimateFees()
+	if err != nil {
+		return err
+	}
+
+	reply.Simple = estimate.Simple
+	reply.Zether = estimate.Zether
+	return nil
+}
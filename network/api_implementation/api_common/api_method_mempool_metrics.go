@@ -0,0 +1,24 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/config"
+	"pandora-pay/mempool"
+)
+
+type APIMempoolMetricsReply struct {
+	Count   int32                   `json:"count" msgpack:"count"`
+	Size    uint64                  `json:"size" msgpack:"size"`
+	MaxTxs  uint64                  `json:"maxTxs" msgpack:"maxTxs"`
+	MaxSize uint64                  `json:"maxSize" msgpack:"maxSize"`
+	Metrics *mempool.MempoolMetrics `json:"metrics" msgpack:"metrics"`
+}
+
+func (api *APICommon) GetMempoolMetrics(r *http.Request, args *struct{}, reply *APIMempoolMetricsReply) error {
+	reply.Count = api.mempool.Txs.GetCount()
+	reply.Size = api.mempool.Txs.GetSize()
+	reply.MaxTxs = config.MEMPOOL_MAX_TXS
+	reply.MaxSize = config.MEMPOOL_MAX_SIZE
+	reply.Metrics = api.mempool.Txs.Metrics.Get()
+	return nil
+}
@@ -0,0 +1,119 @@
+package api_common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/config"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+)
+
+type APIMempoolNewTxsRequest struct {
+	Txs     []helpers.Base64  `json:"txs,omitempty" msgpack:"txs,omitempty"`
+	JsonTxs []json.RawMessage `json:"jsonTxs,omitempty" msgpack:"jsonTxs,omitempty"`
+}
+
+type APIMempoolNewTxsResult struct {
+	Hash   []byte `json:"hash,omitempty" msgpack:"hash,omitempty"`
+	Result bool   `json:"result" msgpack:"result"`
+	Error  string `json:"error,omitempty" msgpack:"error,omitempty"`
+}
+
+//the results have the order of the request. The serialized txs are first, followed by the json ones
+type APIMempoolNewTxsReply struct {
+	Results []*APIMempoolNewTxsResult `json:"results" msgpack:"results"`
+}
+
+func (api *APICommon) MempoolNewTxs(r *http.Request, args *APIMempoolNewTxsRequest, reply *APIMempoolNewTxsReply) (err error) {
+
+	count := len(args.Txs) + len(args.JsonTxs)
+	if count == 0 {
+		return errors.New("No transactions")
+	}
+	if count > config.API_MEMPOOL_NEW_TXS_MAX {
+		return fmt.Errorf("Too many transactions to process: limit %d, found %d", config.API_MEMPOOL_NEW_TXS_MAX, count)
+	}
+
+	reply.Results = make([]*APIMempoolNewTxsResult, count)
+	txs := make([]*transaction.Transaction, count)
+
+	for i, data := range args.Txs {
+		reply.Results[i] = &APIMempoolNewTxsResult{}
+		tx := &transaction.Transaction{}
+		if err = tx.Deserialize(advanced_buffers.NewBufferReader(data)); err != nil {
+			reply.Results[i].Error = err.Error()
+			continue
+		}
+		txs[i] = tx
+	}
+
+	for i, data := range args.JsonTxs {
+		index := len(args.Txs) + i
+		reply.Results[index] = &APIMempoolNewTxsResult{}
+		tx := &transaction.Transaction{}
+		if err = json.Unmarshal(data, tx); err == nil {
+			err = tx.BloomAll()
+		}
+		if err != nil {
+			reply.Results[index].Error = err.Error()
+			continue
+		}
+		txs[index] = tx
+	}
+
+	newTxs := make([]*transaction.Transaction, 0, count)
+	newTxsIndexes := make([]int, 0, count)
+	senders := make(map[string]uint64)
+
+	for i, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		reply.Results[i].Hash = tx.Bloom.Hash
+		if api.mempool.Txs.Exists(tx.Bloom.HashStr) {
+			reply.Results[i].Result = true
+			continue
+		}
+		//the txs of a sender over its quota are rejected before they reach the mempool
+		if tx.Version == transaction_type.TX_SIMPLE {
+			if txBase := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple); txBase.HasVin() {
+				if senders[string(txBase.Vin.PublicKey)] >= config.MEMPOOL_MAX_ACCOUNT_TXS {
+					reply.Results[i].Error = "Too many transactions of the same sender"
+					continue
+				}
+				senders[string(txBase.Vin.PublicKey)] += 1
+			}
+		}
+		newTxs = append(newTxs, tx)
+		newTxsIndexes = append(newTxsIndexes, i)
+	}
+
+	if len(newTxs) == 0 {
+		return nil
+	}
+
+	//the processing stops when the client goes away
+	ctx := context.Background()
+	if r != nil {
+		ctx = r.Context()
+	}
+
+	errs := api.mempool.AddTxsToMempool(newTxs, api.chain.GetChainData().Height, false, true, false, advanced_connection_types.UUID_ALL, ctx)
+	for i, err := range errs {
+		result := reply.Results[newTxsIndexes[i]]
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Result = true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package api_common
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/network/banned_nodes"
+	"pandora-pay/network/connected_nodes"
+	"time"
+)
+
+type APINetworkBansReply struct {
+	Bans []*banned_nodes.BannedNode `json:"bans" msgpack:"bans"`
+}
+
+type APINetworkBansAddRequest struct {
+	URL      string `json:"url" msgpack:"url"`           //url of the node or the ip for the incoming connections
+	Duration uint64 `json:"duration" msgpack:"duration"` //seconds
+	Message  string `json:"message,omitempty" msgpack:"message,omitempty"`
+}
+
+type APINetworkBansAddReply struct {
+	Ban *banned_nodes.BannedNode `json:"ban" msgpack:"ban"`
+}
+
+type APINetworkBansRemoveRequest struct {
+	URL string `json:"url" msgpack:"url"`
+}
+
+type APINetworkBansRemoveReply struct {
+	Status bool `json:"status" msgpack:"status"`
+}
+
+func (api *APICommon) GetNetworkBans(r *http.Request, args *struct{}, reply *APINetworkBansReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Bans = banned_nodes.BannedNodes.GetList()
+	return nil
+}
+
+func (api *APICommon) NetworkBansAdd(r *http.Request, args *APINetworkBansAddRequest, reply *APINetworkBansAddReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	if args.URL == "" {
+		return errors.New("url is empty")
+	}
+	if args.Duration == 0 {
+		return errors.New("Duration is zero")
+	}
+
+	message := args.Message
+	if message == "" {
+		message = "Banned by the operator"
+	}
+
+	reply.Ban = banned_nodes.BannedNodes.Ban(nil, args.URL, message, time.Duration(args.Duration)*time.Second)
+
+	for _, conn := range connected_nodes.ConnectedNodes.AllList.Get() {
+		if conn.GetBanKey() == args.URL {
+			conn.Close()
+		}
+	}
+
+	return nil
+}
+
+func (api *APICommon) NetworkBansRemove(r *http.Request, args *APINetworkBansRemoveRequest, reply *APINetworkBansRemoveReply, authenticated bool) error {
+	if !authenticated {
+		return errors.New("Invalid User or Password")
+	}
+
+	reply.Status = banned_nodes.BannedNodes.Unban(args.URL)
+	return nil
+}
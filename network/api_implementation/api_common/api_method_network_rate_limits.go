@@ -0,0 +1,24 @@
+package api_common
+
+import (
+	"net/http"
+	"pandora-pay/network/network_config"
+	"pandora-pay/network/rate_limiter"
+)
+
+type APINetworkRateLimitsReply struct {
+	IP         uint64                           `json:"ip" msgpack:"ip"` //requests per second. Zero when it is disabled
+	Connection uint64                           `json:"connection" msgpack:"connection"`
+	Expensive  uint64                           `json:"expensive" msgpack:"expensive"`
+	Consensus  uint64                           `json:"consensus" msgpack:"consensus"`
+	Metrics    *rate_limiter.RateLimiterMetrics `json:"metrics" msgpack:"metrics"`
+}
+
+func (api *APICommon) GetNetworkRateLimits(r *http.Request, args *struct{}, reply *APINetworkRateLimitsReply) error {
+	reply.IP = network_config.NETWORK_RATE_LIMIT_IP
+	reply.Connection = network_config.NETWORK_RATE_LIMIT_CONNECTION
+	reply.Expensive = network_config.NETWORK_RATE_LIMIT_EXPENSIVE
+	reply.Consensus = network_config.NETWORK_RATE_LIMIT_CONSENSUS
+	reply.Metrics = rate_limiter.RateLimiter.GetMetrics()
+	return nil
+}
@@ -43,6 +43,9 @@ func (api *APICommon) openLoadTx(args *APITxRequest, reply *APITxReply) error {
 		var data []byte
 
 		if data = reader.Get("tx:" + hashStr); data == nil {
+			if err = api.ApiStore.checkTxPruned(reader, args.Hash); err != nil {
+				return
+			}
 			return errors.New("Tx not found")
 		}
 
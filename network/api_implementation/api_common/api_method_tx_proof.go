@@ -0,0 +1,58 @@
+package api_common
+
+import (
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APITxProofRequest struct {
+	Hash helpers.Base64 `json:"hash,omitempty" msgpack:"hash,omitempty"`
+}
+
+type APITxProofReply struct {
+	TxHash         []byte   `json:"txHash" msgpack:"txHash"`
+	BlockHeight    uint64   `json:"blockHeight" msgpack:"blockHeight"`
+	BlockHash      []byte   `json:"blockHash" msgpack:"blockHash"`
+	BlockTimestamp uint64   `json:"blockTimestamp" msgpack:"blockTimestamp"`
+	MerkleHash     []byte   `json:"merkleHash" msgpack:"merkleHash"`
+	MerkleIndex    int      `json:"merkleIndex" msgpack:"merkleIndex"`
+	MerklePath     [][]byte `json:"merklePath" msgpack:"merklePath"`
+}
+
+func (api *APICommon) loadTxProof(reader store_db_interface.StoreDBTransactionInterface, txHash []byte, blockHeight uint64, reply *APITxProofReply) (err error) {
+
+	blk, index, proof, err := api.ApiStore.loadTxMerkleProof(reader, txHash, blockHeight)
+	if err != nil {
+		return
+	}
+
+	reply.TxHash = txHash
+	reply.BlockHeight = blk.Height
+	reply.BlockHash = blk.Bloom.Hash
+	reply.BlockTimestamp = blk.Timestamp
+	reply.MerkleHash = blk.MerkleHash
+	reply.MerkleIndex = index
+	reply.MerklePath = proof
+	return
+}
+
+func (api *APICommon) GetTxProof(r *http.Request, args *APITxProofRequest, reply *APITxProofReply) error {
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+
+		data := reader.Get("txBlock:" + string(args.Hash))
+		if data == nil {
+			return errors.New("Tx was not found in a block")
+		}
+
+		blockHeight, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("Tx block height is invalid")
+		}
+
+		return api.loadTxProof(reader, args.Hash, blockHeight, reply)
+	})
+}
@@ -0,0 +1,118 @@
+package api_common
+
+import (
+	"fmt"
+	"net/http"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/txs_validator"
+)
+
+type APITxSimulateRequest struct {
+	Tx helpers.Base64 `json:"tx" msgpack:"tx"`
+}
+
+type APITxSimulateAccounts struct {
+	Asset      []byte   `json:"asset" msgpack:"asset"`
+	PublicKeys [][]byte `json:"publicKeys" msgpack:"publicKeys"`
+}
+
+type APITxSimulateConditionalPayment struct {
+	BlockHeight  uint64 `json:"blockHeight" msgpack:"blockHeight"`
+	TxId         []byte `json:"txId" msgpack:"txId"`
+	PayloadIndex byte   `json:"payloadIndex" msgpack:"payloadIndex"`
+}
+
+type APITxSimulateReply struct {
+	Hash                []byte                             `json:"hash" msgpack:"hash"`
+	Height              uint64                             `json:"height" msgpack:"height"`
+	Valid               bool                               `json:"valid" msgpack:"valid"`
+	Error               string                             `json:"error,omitempty" msgpack:"error,omitempty"`
+	Accounts            []*APITxSimulateAccounts           `json:"accounts,omitempty" msgpack:"accounts,omitempty"`
+	PlainAccounts       [][]byte                           `json:"plainAccounts,omitempty" msgpack:"plainAccounts,omitempty"`
+	Assets              [][]byte                           `json:"assets,omitempty" msgpack:"assets,omitempty"`
+	Registrations       [][]byte                           `json:"registrations,omitempty" msgpack:"registrations,omitempty"`
+	ConditionalPayments []*APITxSimulateConditionalPayment `json:"conditionalPayments,omitempty" msgpack:"conditionalPayments,omitempty"`
+}
+
+func toBytesList(keys []string) [][]byte {
+	out := make([][]byte, len(keys))
+	for i, key := range keys {
+		out[i] = []byte(key)
+	}
+	return out
+}
+
+func (reply *APITxSimulateReply) setChanges(dataStorage *data_storage.DataStorage) (err error) {
+
+	for assetId, accs := range dataStorage.AccsCollection.GetAllMaps() {
+		if keys := accs.GetChangedKeys(); len(keys) > 0 {
+			reply.Accounts = append(reply.Accounts, &APITxSimulateAccounts{[]byte(assetId), toBytesList(keys)})
+		}
+	}
+
+	reply.PlainAccounts = toBytesList(dataStorage.PlainAccs.GetChangedKeys())
+	reply.Assets = toBytesList(dataStorage.Asts.GetChangedKeys())
+	reply.Registrations = toBytesList(dataStorage.Regs.GetChangedKeys())
+
+	for _, condPayments := range dataStorage.ConditionalPaymentsCollection.GetAllMaps() {
+		for _, key := range condPayments.GetChangedKeys() {
+			condPayment, err := condPayments.Get(key)
+			if err != nil {
+				return err
+			}
+			if condPayment != nil {
+				reply.ConditionalPayments = append(reply.ConditionalPayments, &APITxSimulateConditionalPayment{condPayments.BlockHeight, condPayment.TxId, condPayment.PayloadIndex})
+			}
+		}
+	}
+
+	return
+}
+
+//the malformed txs could panic while they are included, so the panic is returned as the error of the tx
+func simulateTx(tx *transaction.Transaction, height uint64, dataStorage *data_storage.DataStorage) (err error) {
+
+	defer func() {
+		if errReturned := recover(); errReturned != nil {
+			err = fmt.Errorf("Transaction simulation failed: %v", errReturned)
+		}
+	}()
+
+	return tx.IncludeTransaction(height, dataStorage)
+}
+
+//the tx is included in a throwaway data storage over a read only view. Nothing is committed or broadcast
+func (api *APICommon) TxSimulate(r *http.Request, args *APITxSimulateRequest, reply *APITxSimulateReply) (err error) {
+
+	tx := &transaction.Transaction{}
+	if err = tx.Deserialize(advanced_buffers.NewBufferReader(args.Tx)); err != nil {
+		return
+	}
+
+	reply.Hash = tx.Bloom.Hash
+
+	if err := txs_validator.TxsValidator.ValidateTx(tx); err != nil {
+		reply.Error = err.Error()
+		return nil
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		reply.Height = api.chain.GetChainData().Height
+
+		dataStorage := data_storage.NewDataStorage(reader)
+
+		if err := simulateTx(tx, reply.Height, dataStorage); err != nil {
+			reply.Error = err.Error()
+			return nil
+		}
+
+		reply.Valid = true
+		return reply.setChanges(dataStorage)
+	})
+}
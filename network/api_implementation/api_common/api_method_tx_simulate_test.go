@@ -0,0 +1,82 @@
+package api_common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"pandora-pay/txs_builder/wizard"
+	"testing"
+)
+
+func newTestSimulateTx(t *testing.T, key *addresses.PrivateKey, nonce uint64) *transaction.Transaction {
+	tx, err := wizard.CreateSimpleTx(&wizard.WizardTxSimpleTransfer{
+		&wizard.WizardTxSimpleExtraUpdateAssetFeeLiquidity{nil, nil, false, nil},
+		&wizard.WizardTransactionData{},
+		&wizard.WizardTransactionFee{20000, 0, 0, false},
+		nonce,
+		0,
+		key.Key,
+	}, true, func(string) {})
+	assert.Nil(t, err)
+	return tx
+}
+
+func TestSimulateTx(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.Nil(t, err)
+
+	key := addresses.GenerateNewPrivateKey()
+	publicKey := key.GeneratePublicKey()
+
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(writer)
+		plainAcc, err := dataStorage.CreatePlainAccount(publicKey, false)
+		assert.Nil(t, err)
+		assert.Nil(t, plainAcc.AddUnclaimed(true, config_coins.ConvertToUnitsUint64Forced(1000)))
+		assert.Nil(t, dataStorage.PlainAccs.Update(string(publicKey), plainAcc))
+		assert.Nil(t, dataStorage.CommitChanges())
+		return
+	}))
+
+	//the panics of the malformed txs are returned as errors
+	malformed := &transaction.Transaction{
+		Version: transaction_type.TX_SIMPLE,
+		TransactionBaseInterface: &transaction_simple.TransactionSimple{
+			TxScript: transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY,
+			Extra:    &transaction_simple_extra.TransactionSimpleExtraUpdateAssetFeeLiquidity{},
+		},
+		Bloom: &transaction.TransactionBloom{},
+	}
+
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		dataStorage := data_storage.NewDataStorage(reader)
+		assert.Nil(t, simulateTx(newTestSimulateTx(t, key, 0), 10, dataStorage))
+
+		reply := &APITxSimulateReply{}
+		assert.Nil(t, reply.setChanges(dataStorage))
+		assert.Equal(t, [][]byte{publicKey}, reply.PlainAccounts)
+
+		assert.NotNil(t, simulateTx(newTestSimulateTx(t, key, 5), 10, data_storage.NewDataStorage(reader)))
+		assert.NotNil(t, simulateTx(malformed, 10, data_storage.NewDataStorage(reader)))
+
+		return
+	}))
+
+	//nothing was committed
+	assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		plainAcc, err := data_storage.NewDataStorage(reader).PlainAccs.Get(string(publicKey))
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(0), plainAcc.Nonce)
+		return
+	}))
+
+}
@@ -37,7 +37,11 @@ func (api *APICommon) GetWalletDecryptTx(r *http.Request, args *APIWalletDecrypt
 	var txSerialized []byte
 	if err = store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
 
-		txSerialized = reader.Get("tx:" + string(args.Hash))
+		if txSerialized = reader.Get("tx:" + string(args.Hash)); txSerialized == nil {
+			if err = api.ApiStore.checkTxPruned(reader, args.Hash); err != nil {
+				return
+			}
+		}
 
 		if data := reader.Get("txBlock:" + string(args.Hash)); data != nil {
 			var blockHeight, chainHeight uint64
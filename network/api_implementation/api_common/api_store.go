@@ -1,10 +1,14 @@
 package api_common
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"pandora-pay/blockchain"
 	"pandora-pay/blockchain/blocks/block"
 	"pandora-pay/blockchain/info"
+	"pandora-pay/cryptography/merkle_tree"
+	"pandora-pay/helpers"
 	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/store/store_db/store_db_interface"
@@ -42,7 +46,7 @@ func (apiStore *APIStore) loadTxHash(reader store_db_interface.StoreDBTransactio
 	if height < 0 {
 		return nil, errors.New("Height is invalid")
 	}
-	return reader.Get("txHash_ByHeight" + strconv.FormatUint(height, 10)), nil
+	return reader.Get("txHash_ByHeight" + helpers.FormatIndexKey(height)), nil
 }
 
 func (chain *APIStore) loadBlock(reader store_db_interface.StoreDBTransactionInterface, hash []byte) (*block.Block, error) {
@@ -54,6 +58,57 @@ func (chain *APIStore) loadBlock(reader store_db_interface.StoreDBTransactionInt
 	return blk, blk.Deserialize(advanced_buffers.NewBufferReader(blockData))
 }
 
+func (apiStore *APIStore) loadTxMerkleProof(reader store_db_interface.StoreDBTransactionInterface, txHash []byte, blockHeight uint64) (blk *block.Block, index int, proof [][]byte, err error) {
+
+	blockHash, err := apiStore.chain.LoadBlockHash(reader, blockHeight)
+	if err != nil {
+		return
+	}
+	if blk, err = apiStore.loadBlock(reader, blockHash); err != nil {
+		return
+	}
+
+	if err = apiStore.checkBlockPruned(reader, blockHeight); err != nil {
+		return
+	}
+
+	txHashes := [][]byte{}
+	if err = msgpack.Unmarshal(reader.Get("blockTxs"+strconv.FormatUint(blockHeight, 10)), &txHashes); err != nil {
+		return
+	}
+
+	index = -1
+	for i := range txHashes {
+		if bytes.Equal(txHashes[i], txHash) {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, 0, nil, errors.New("Tx was not found in the block")
+	}
+
+	proof, err = merkle_tree.MerkleProof(txHashes, index)
+	return
+}
+
+//returns an error when the transactions of the block were discarded by --prune
+func (apiStore *APIStore) checkBlockPruned(reader store_db_interface.StoreDBTransactionInterface, blockHeight uint64) error {
+	if prunedHeight := blockchain.LoadPrunedHeight(reader); blockHeight < prunedHeight {
+		return errors.New("Block " + strconv.FormatUint(blockHeight, 10) + " was pruned. Only the blocks starting with " + strconv.FormatUint(prunedHeight, 10) + " are available")
+	}
+	return nil
+}
+
+//the pruned txs keep only txBlock:
+func (apiStore *APIStore) checkTxPruned(reader store_db_interface.StoreDBTransactionInterface, hash []byte) error {
+	if data := reader.Get("txBlock:" + string(hash)); data != nil {
+		blockHeight, _ := binary.Uvarint(data)
+		return apiStore.checkBlockPruned(reader, blockHeight)
+	}
+	return nil
+}
+
 func NewAPIStore(chain *blockchain.Blockchain) *APIStore {
 	return &APIStore{
 		chain: chain,
@@ -51,3 +51,16 @@ type APISubscriptionNotificationTxExtra struct {
 	Blockchain *APISubscriptionNotificationTxExtraBlockchain `json:"blockchain,omitempty" msgpack:"blockchain,omitempty"`
 	Mempool    *APISubscriptionNotificationTxExtraMempool    `json:"mempool,omitempty" msgpack:"mempool,omitempty"`
 }
+
+type APISubscriptionNotificationBlockExtra struct {
+	Height     uint64   `json:"height" msgpack:"height"`
+	Txs        [][]byte `json:"txs" msgpack:"txs"`
+	RemovedTxs [][]byte `json:"removedTxs,omitempty" msgpack:"removedTxs,omitempty"` //only in the first block of an update
+}
+
+type APISubscriptionNotificationMempoolExtra struct {
+	Inserted   bool   `json:"inserted,omitempty" msgpack:"inserted,omitempty"`
+	Included   bool   `json:"included,omitempty" msgpack:"included,omitempty"`
+	FeePerByte uint64 `json:"feePerByte" msgpack:"feePerByte"`
+	Size       uint64 `json:"size" msgpack:"size"`
+}
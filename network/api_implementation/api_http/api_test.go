@@ -0,0 +1,34 @@
+package api_http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/network/network_config/network_config_auth"
+	"testing"
+)
+
+func TestNewAPI_Authenticated(t *testing.T) {
+
+	var err error
+	if gui.GUI == nil {
+		gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+		assert.Nil(t, err)
+	}
+
+	//every authenticated method must be in the permissions table
+	var api *API
+	assert.NotPanics(t, func() { api = NewAPI(nil, &api_common.APICommon{}, nil) })
+
+	network_config_auth.CONFIG_AUTH_USERS_MAP = map[string]*network_config_auth.ConfigAuth{
+		"reader": {"reader", "pass", network_config_auth.AUTH_ROLE_READ},
+	}
+	defer func() { network_config_auth.CONFIG_AUTH_USERS_MAP = nil }()
+
+	for _, method := range []string{"network/bans", "network/bans/add", "network/bans/remove", "wallet/generate-address", "wallet/create-address", "wallet/delete-address"} {
+		_, err = api.GetMap[method](url.Values{"user": {"reader"}, "pass": {"pass"}})
+		assert.EqualError(t, err, "Permission denied", method)
+	}
+}
@@ -28,6 +28,13 @@ type APIWebsockets struct {
 
 var ConfigureAPIRoutes func(api *APIWebsockets)
 
+//the authenticated methods get their name from the map key
+func (api *APIWebsockets) registerAuthenticated(methods map[string]func(method string) func(conn *connection.AdvancedConnection, values []byte) (interface{}, error)) {
+	for method, handler := range methods {
+		api.GetMap[method] = handler(method)
+	}
+}
+
 func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICommon, chain *blockchain.Blockchain, settings *settings.Settings, mempool *mempool.Mempool) *APIWebsockets {
 
 	api := &APIWebsockets{
@@ -57,7 +64,9 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 		"tx-hash":                 api_code_websockets.Handle[api_common.APITxHashRequest, api_common.APITxHashReply](api.apiCommon.GetTxHash),
 		"tx":                      api_code_websockets.Handle[api_common.APITxRequest, api_common.APITxReply](api.apiCommon.GetTx),
 		"tx/exists":               api_code_websockets.Handle[api_common.APITxExistsRequest, api_common.APITxExistsReply](api.apiCommon.GetTxExists),
+		"tx/proof":                api_code_websockets.Handle[api_common.APITxProofRequest, api_common.APITxProofReply](api.apiCommon.GetTxProof),
 		"tx-raw":                  api_code_websockets.Handle[api_common.APITxRawRequest, api_common.APITxRawReply](api.apiCommon.GetTxRaw),
+		"tx/simulate":             api_code_websockets.Handle[api_common.APITxSimulateRequest, api_common.APITxSimulateReply](api.apiCommon.TxSimulate),
 		"account":                 api_code_websockets.Handle[api_common.APIAccountRequest, api_common.APIAccountReply](api.apiCommon.GetAccount),
 		"accounts/count":          api_code_websockets.Handle[api_common.APIAccountsCountRequest, api_common.APIAccountsCountReply](api.apiCommon.GetAccountsCount),
 		"accounts/keys-by-index":  api_code_websockets.Handle[api_common.APIAccountsKeysByIndexRequest, api_common.APIAccountsKeysByIndexReply](api.apiCommon.GetAccountsKeysByIndex),
@@ -65,29 +74,46 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 		"asset":                   api_code_websockets.Handle[api_common.APIAssetRequest, api_common.APIAssetReply](api.apiCommon.GetAsset),
 		"asset/exists":            api_code_websockets.Handle[api_common.APIAssetRequest, api_common.APIAssetReply](api.apiCommon.GetAsset),
 		"asset/fee-liquidity":     api_code_websockets.Handle[api_common.APIAssetFeeLiquidityFeeRequest, api_common.APIAssetFeeLiquidityFeeReply](api.apiCommon.GetAssetFeeLiquidity),
+		"ballot":                  api_code_websockets.Handle[api_common.APIBallotRequest, api_common.APIBallotReply](api.apiCommon.GetBallot),
+		"identity":                api_code_websockets.Handle[api_common.APIIdentityRequest, api_common.APIIdentityReply](api.apiCommon.GetIdentity),
+		"identity/attestations":   api_code_websockets.Handle[api_common.APIIdentityAttestationsRequest, api_common.APIIdentityAttestationsReply](api.apiCommon.GetIdentityAttestations),
+		"document/proof":          api_code_websockets.Handle[api_common.APIDocumentProofRequest, api_common.APIDocumentProofReply](api.apiCommon.GetDocumentProof),
 		"mempool":                 api_code_websockets.Handle[api_common.APIMempoolRequest, api_common.APIMempoolReply](api.apiCommon.GetMempool),
 		"mempool/tx-exists":       api_code_websockets.Handle[api_common.APIMempoolExistsRequest, api_common.APIMempoolExistsReply](api.apiCommon.GetMempoolExists),
 		"mempool/new-tx":          api_code_websockets.Handle[api_common.APIMempoolNewTxRequest, api_common.APIMempoolNewTxReply](api.apiCommon.MempoolNewTx),
+		"mempool/new-txs":         api_code_websockets.Handle[api_common.APIMempoolNewTxsRequest, api_common.APIMempoolNewTxsReply](api.apiCommon.MempoolNewTxs),
+		"mempool/fee-estimate":    api_code_websockets.Handle[struct{}, api_common.APIMempoolFeeEstimateReply](api.apiCommon.GetMempoolFeeEstimate),
+		"mempool/metrics":         api_code_websockets.Handle[struct{}, api_common.APIMempoolMetricsReply](api.apiCommon.GetMempoolMetrics),
 		"network/nodes":           api_code_websockets.Handle[struct{}, api_common.APINetworkNodesReply](api.apiCommon.GetNetworkNodes),
-		"wallet/get-addresses":    api_code_websockets.HandleAuthenticated[struct{}, api_common.APIWalletGetAccountsReply](api.apiCommon.GetWalletAddresses),
-		"wallet/generate-address": api_code_websockets.HandleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
-		"wallet/create-address":   api_code_websockets.HandleAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api.apiCommon.GetWalletCreateAddress),
-		"wallet/delete-address":   api_code_websockets.HandleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
-		"wallet/get-balances":     api_code_websockets.HandleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
-		"wallet/decrypt-tx":       api_code_websockets.HandleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
-		"wallet/private-transfer": api_code_websockets.HandleAuthenticated[api_common.APIWalletPrivateTransferRequest, api_common.APIWalletPrivateTransferReply](api.apiCommon.WalletPrivateTransfer),
+		"network/rate-limits":     api_code_websockets.Handle[struct{}, api_common.APINetworkRateLimitsReply](api.apiCommon.GetNetworkRateLimits),
 		//below are ONLY websockets API
 		"block-miss-txs":    api_code_websockets.Handle[consensus.APIBlockCompleteMissingTxsRequest, consensus.APIBlockCompleteMissingTxsReply](api.Consensus.GetBlockCompleteMissingTxs),
 		"handshake":         api_code_websockets.Handshake,
 		"mempool/new-tx-id": api.apiCommon.MempoolNewTxId,
 		"get-chain":         api.Consensus.GetChain,
+		"get-headers":       api_code_websockets.Handle[consensus.APIGetHeadersRequest, consensus.APIGetHeadersReply](api.Consensus.GetHeaders),
 		"chain-update":      api.Consensus.ChainUpdate,
 		"login":             api_code_websockets.Login,
+		"login-challenge":   api_code_websockets.LoginChallenge,
+		"login-key":         api_code_websockets.LoginKey,
 		"logout":            api_code_websockets.Logout,
 		"sub":               api_code_websockets.Subscribe,
 		"unsub":             api_code_websockets.Unsubscribe,
 	}
 
+	api.registerAuthenticated(map[string]func(method string) func(conn *connection.AdvancedConnection, values []byte) (interface{}, error){
+		"network/bans":            api_code_websockets.HandleAuthenticated[struct{}, api_common.APINetworkBansReply](api.apiCommon.GetNetworkBans),
+		"network/bans/add":        api_code_websockets.HandleAuthenticated[api_common.APINetworkBansAddRequest, api_common.APINetworkBansAddReply](api.apiCommon.NetworkBansAdd),
+		"network/bans/remove":     api_code_websockets.HandleAuthenticated[api_common.APINetworkBansRemoveRequest, api_common.APINetworkBansRemoveReply](api.apiCommon.NetworkBansRemove),
+		"wallet/get-addresses":    api_code_websockets.HandleAuthenticated[struct{}, api_common.APIWalletGetAccountsReply](api.apiCommon.GetWalletAddresses),
+		"wallet/generate-address": api_code_websockets.HandleAuthenticated[api_common.APIWalletGenerateAddressRequest, api_common.APIWalletGenerateAddressReply](api.apiCommon.GetWalletGenerateAddress),
+		"wallet/create-address":   api_code_websockets.HandleAuthenticated[api_common.APIWalletCreateAddressRequest, api_common.APIWalletCreateAddressReply](api.apiCommon.GetWalletCreateAddress),
+		"wallet/delete-address":   api_code_websockets.HandleAuthenticated[api_common.APIWalletDeleteAddressRequest, api_common.APIWalletDeleteAddressReply](api.apiCommon.GetWalletDeleteAddress),
+		"wallet/get-balances":     api_code_websockets.HandleAuthenticated[api_common.APIWalletGetBalanceRequest, api_common.APIWalletGetBalancesReply](api.apiCommon.GetWalletBalances),
+		"wallet/decrypt-tx":       api_code_websockets.HandleAuthenticated[api_common.APIWalletDecryptTxRequest, api_common.APIWalletDecryptTxReply](api.apiCommon.GetWalletDecryptTx),
+		"wallet/private-transfer": api_code_websockets.HandleAuthenticated[api_common.APIWalletPrivateTransferRequest, api_common.APIWalletPrivateTransferReply](api.apiCommon.WalletPrivateTransfer),
+	})
+
 	if config.NODE_PROVIDE_EXTENDED_INFO_APP {
 		api.GetMap["asset-info"] = api_code_websockets.Handle[api_common.APIAssetInfoRequest, info.AssetInfo](api.apiCommon.GetAssetInfo)
 		api.GetMap["block-info"] = api_code_websockets.Handle[api_common.APIBlockInfoRequest, info.BlockInfo](api.apiCommon.GetBlockInfo)
@@ -111,7 +137,9 @@ func NewWebsocketsAPI(apiStore *api_common.APIStore, apiCommon *api_common.APICo
 
 	if api.apiCommon.DelegatorNode != nil {
 		api.GetMap["delegator-node/info"] = api_code_websockets.Handle[struct{}, api_delegator_node.ApiDelegatorNodeInfoReply](api.apiCommon.DelegatorNode.GetDelegatorNodeInfo)
-		api.GetMap["delegator-node/notify"] = api_code_websockets.HandleAuthenticated[api_delegator_node.ApiDelegatorNodeNotifyRequest, api_delegator_node.ApiDelegatorNodeNotifyReply](api.apiCommon.DelegatorNode.DelegatorNotify)
+		api.registerAuthenticated(map[string]func(method string) func(conn *connection.AdvancedConnection, values []byte) (interface{}, error){
+			"delegator-node/notify": api_code_websockets.HandleAuthenticated[api_delegator_node.ApiDelegatorNodeNotifyRequest, api_delegator_node.ApiDelegatorNodeNotifyReply](api.apiCommon.DelegatorNode.DelegatorNotify),
+		})
 	}
 
 	if ConfigureAPIRoutes != nil {
@@ -3,6 +3,7 @@ package consensus
 import (
 	"errors"
 	"net/http"
+	"pandora-pay/blockchain"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/store"
@@ -32,6 +33,10 @@ func (api *Consensus) GetBlockCompleteMissingTxs(r *http.Request, args *APIBlock
 			return
 		}
 
+		if height < blockchain.LoadPrunedHeight(reader) {
+			return errors.New("Block was pruned")
+		}
+
 		data := reader.Get("blockTxs" + strconv.FormatUint(height, 10))
 		if data == nil {
 			return errors.New("Block not found")
@@ -7,12 +7,14 @@ import (
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers/linked_list"
 	"pandora-pay/helpers/msgpack"
+	"pandora-pay/network/network_config"
 	"pandora-pay/network/websocks/connection"
 )
 
 func (consensus *Consensus) ChainUpdateProcess(conn *connection.AdvancedConnection, chainUpdateNotification *ChainUpdateNotification) (interface{}, error) {
 
 	if len(chainUpdateNotification.Hash) != cryptography.HashSize {
+		conn.Penalize(network_config.NETWORK_PENALTY_INVALID_CHAIN_UPDATE, "Invalid chain update")
 		return nil, errors.New("Chain Update Hash Length is invalid")
 	}
 
@@ -60,6 +62,7 @@ func (consensus *Consensus) ChainUpdateProcess(conn *connection.AdvancedConnecti
 func (consensus *Consensus) ChainUpdate(conn *connection.AdvancedConnection, data []byte) (interface{}, error) {
 	chainUpdateNotification := &ChainUpdateNotification{}
 	if err := msgpack.Unmarshal(data, chainUpdateNotification); err != nil {
+		conn.Penalize(network_config.NETWORK_PENALTY_INVALID_CHAIN_UPDATE, "Invalid chain update")
 		return nil, err
 	}
 	return consensus.ChainUpdateProcess(conn, chainUpdateNotification)
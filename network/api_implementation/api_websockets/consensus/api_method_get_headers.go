@@ -0,0 +1,48 @@
+package consensus
+
+import (
+	"errors"
+	"net/http"
+	"pandora-pay/config"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type APIGetHeadersRequest struct {
+	Start uint64 `json:"start" msgpack:"start"`
+	Count uint64 `json:"count" msgpack:"count"`
+}
+
+type APIGetHeadersReply struct {
+	Headers [][]byte `json:"headers" msgpack:"headers"`
+}
+
+func (api *Consensus) GetHeaders(r *http.Request, args *APIGetHeadersRequest, reply *APIGetHeadersReply) error {
+
+	if args.Count == 0 || args.Count > config.HEADERS_MAX_DOWNLOAD {
+		return errors.New("Headers count is invalid")
+	}
+
+	return store.StoreBlockchain.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		reply.Headers = make([][]byte, 0, args.Count)
+		for height := args.Start; height < args.Start+args.Count; height++ {
+
+			hash, err := api.chain.LoadBlockHash(reader, height)
+			if err != nil {
+				break
+			}
+
+			data := reader.Get("block_ByHash" + string(hash))
+			if data == nil {
+				return errors.New("Block was not found")
+			}
+			reply.Headers = append(reply.Headers, data)
+		}
+
+		if len(reply.Headers) == 0 {
+			return errors.New("Headers were not found")
+		}
+		return
+	})
+}
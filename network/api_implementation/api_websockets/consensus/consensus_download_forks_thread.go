@@ -15,6 +15,7 @@ import (
 	"pandora-pay/mempool"
 	"pandora-pay/network/api_code/api_code_types"
 	"pandora-pay/network/api_implementation/api_common"
+	"pandora-pay/network/network_config"
 	"pandora-pay/network/websocks/connection"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/txs_validator"
@@ -47,9 +48,15 @@ func (thread *ConsensusProcessForksThread) downloadBlockComplete(conn *connectio
 		return nil, err
 	}
 
+	//the peer answered with invalid data
+	invalid := func(err error) (*block_complete.BlockComplete, error) {
+		conn.Penalize(network_config.NETWORK_PENALTY_INVALID_BLOCK, "Invalid block")
+		return nil, err
+	}
+
 	blkWithTx.Block = block.CreateEmptyBlock()
 	if err = blkWithTx.Block.Deserialize(advanced_buffers.NewBufferReader(blkWithTx.BlockSerialized)); err != nil {
-		return nil, err
+		return invalid(err)
 	}
 
 	txsFound := 0
@@ -88,19 +95,19 @@ func (thread *ConsensusProcessForksThread) downloadBlockComplete(conn *connectio
 		}
 
 		if len(blkCompleteMissingTxs.Txs) != len(missingTxs) {
-			return nil, errors.New("blkCompleteMissingTxs.Txs length is not matching")
+			return invalid(errors.New("blkCompleteMissingTxs.Txs length is not matching"))
 		}
 
 		for _, missingTx := range blkCompleteMissingTxs.Txs {
 			if missingTx == nil {
-				return nil, errors.New("blkCompleteMissingTxs.Tx is null")
+				return invalid(errors.New("blkCompleteMissingTxs.Tx is null"))
 			}
 		}
 
 		for i, missingTx := range missingTxs {
 			tx := &transaction.Transaction{}
 			if err = tx.Deserialize(advanced_buffers.NewBufferReader(blkCompleteMissingTxs.Txs[i])); err != nil {
-				return nil, err
+				return invalid(err)
 			}
 			txs[missingTx] = tx
 		}
@@ -109,11 +116,11 @@ func (thread *ConsensusProcessForksThread) downloadBlockComplete(conn *connectio
 	blkComplete.Txs = txs
 
 	if err = txs_validator.TxsValidator.ValidateTxs(txs); err != nil {
-		return nil, err
+		return invalid(err)
 	}
 
 	if err = blkComplete.BloomAll(); err != nil {
-		return nil, err
+		return invalid(err)
 	}
 
 	return blkComplete, nil
@@ -178,6 +185,7 @@ func (thread *ConsensusProcessForksThread) downloadFork(fork *Fork) bool {
 		}
 
 		if !bytes.Equal(blkComplete.Bloom.Hash, hash) { //it is not the same block
+			conn.Penalize(network_config.NETWORK_PENALTY_INVALID_BLOCK, "Block hash is not matching")
 			fork.errors += 1
 			continue
 		}
@@ -233,6 +241,116 @@ func (thread *ConsensusProcessForksThread) downloadRemainingBlocks(fork *Fork) b
 
 }
 
+func (thread *ConsensusProcessForksThread) downloadHeaders(conn *connection.AdvancedConnection, start, count uint64) ([]*block.Block, error) {
+
+	answer, err := connection.SendJSONAwaitAnswer[APIGetHeadersReply](conn, []byte("get-headers"), &APIGetHeadersRequest{start, count}, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(answer.Headers) == 0 || uint64(len(answer.Headers)) > count {
+		return nil, errors.New("Headers length is invalid")
+	}
+
+	blks := make([]*block.Block, len(answer.Headers))
+	for i := range answer.Headers {
+		blks[i] = block.CreateEmptyBlock()
+		if err = blks[i].Deserialize(advanced_buffers.NewBufferReader(answer.Headers[i])); err != nil {
+			return nil, err
+		}
+		if blks[i].Height != start+uint64(i) {
+			return nil, errors.New("Header height is invalid")
+		}
+	}
+
+	return blks, nil
+}
+
+// it is used by the header only app nodes
+func (thread *ConsensusProcessForksThread) downloadHeadersFork(fork *Fork) bool {
+
+	fork.Lock()
+	defer fork.Unlock()
+
+	chainData := thread.chain.GetChainData()
+	if fork.BigTotalDifficulty.Cmp(chainData.BigTotalDifficulty) <= 0 {
+		return false
+	}
+
+	start := fork.End
+	if start > chainData.Height {
+		start = chainData.Height
+	}
+
+	//finding the common header
+	for start > 0 {
+
+		if chainData.Height-start > config.FORK_MAX_UNCLE_ALLOWED {
+			return false
+		}
+		if fork.errors > 2 {
+			return false
+		}
+
+		conn := fork.getRandomConn()
+		if conn == nil {
+			return false
+		}
+
+		hash, err := thread.downloadBlockHash(conn, fork, start-1)
+		if err != nil {
+			fork.errors += 1
+			continue
+		}
+
+		chainHash, err := thread.chain.OpenLoadBlockHash(start - 1)
+		if err == nil && bytes.Equal(hash, chainHash) {
+			break
+		}
+
+		start -= 1
+	}
+
+	inserted := false
+	for start < fork.End {
+
+		if fork.errors > 2 {
+			break
+		}
+
+		conn := fork.getRandomConn()
+		if conn == nil {
+			break
+		}
+
+		count := fork.End - start
+		if count > config.HEADERS_MAX_DOWNLOAD {
+			count = config.HEADERS_MAX_DOWNLOAD
+		}
+
+		blks, err := thread.downloadHeaders(conn, start, count)
+		if err != nil {
+			fork.errors += 1
+			continue
+		}
+
+		if err = thread.chain.AddHeaders(blks); err != nil {
+			if config.DEBUG {
+				gui.GUI.Error("Invalid Headers", err)
+			}
+			if blockchain.IsInvalidBlock(err) {
+				conn.Penalize(network_config.NETWORK_PENALTY_INVALID_BLOCK, "Invalid headers")
+			}
+			break
+		}
+
+		inserted = true
+		start += uint64(len(blks))
+	}
+
+	return inserted
+}
+
 func (thread *ConsensusProcessForksThread) execute() {
 
 	for {
@@ -263,6 +381,9 @@ func (thread *ConsensusProcessForksThread) execute() {
 							if config.DEBUG {
 								gui.GUI.Error("Invalid Fork", err)
 							}
+							if blockchain.IsInvalidBlock(err) {
+								fork.penalizeConns(network_config.NETWORK_PENALTY_INVALID_BLOCK, "Invalid fork")
+							}
 						} else {
 							fork.Lock()
 							if fork.Current < fork.End {
@@ -276,6 +397,14 @@ func (thread *ConsensusProcessForksThread) execute() {
 					}
 				}
 
+			} else if config.NODE_CONSENSUS_APP_HEADERS {
+
+				if thread.downloadHeadersFork(fork) {
+					globals.MainEvents.BroadcastEvent("consensus/update", fork)
+					chainData := thread.chain.GetChainData()
+					thread.mempool.UpdateWork(chainData.Hash, chainData.Height)
+				}
+
 			} else {
 				globals.MainEvents.BroadcastEvent("consensus/update", fork)
 				gui.GUI.Log("Status. AddBlocks fork - Simulating block")
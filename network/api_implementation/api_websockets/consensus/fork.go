@@ -54,3 +54,13 @@ func (fork *Fork) AddConn(conn *connection.AdvancedConnection, lock bool) {
 
 	fork.conns = append(fork.conns, conn)
 }
+
+func (fork *Fork) penalizeConns(points int32, message string) {
+	fork.RLock()
+	conns := append([]*connection.AdvancedConnection{}, fork.conns...)
+	fork.RUnlock()
+
+	for _, conn := range conns {
+		conn.Penalize(points, message)
+	}
+}
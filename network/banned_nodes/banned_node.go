@@ -1,13 +1,16 @@
 package banned_nodes
 
 import (
-	"net/url"
 	"time"
 )
 
 type BannedNode struct {
-	URL        *url.URL
-	Timestamp  time.Time
-	Expiration time.Time
-	Message    string
+	URL        string    `json:"url" msgpack:"url"`
+	Timestamp  time.Time `json:"timestamp" msgpack:"timestamp"`
+	Expiration time.Time `json:"expiration" msgpack:"expiration"`
+	Message    string    `json:"message" msgpack:"message"`
+}
+
+func (bannedNode *BannedNode) IsExpired(now time.Time) bool {
+	return now.After(bannedNode.Expiration)
 }
@@ -1,33 +1,171 @@
 package banned_nodes
 
 import (
+	"net"
 	"net/url"
+	"pandora-pay/gui"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/recovery"
+	"pandora-pay/network/network_config"
+	"sort"
+	"sync"
 	"time"
 )
 
+type bannedScore struct {
+	score     int32
+	timestamp time.Time
+}
+
 type BannedNodesType struct {
-	bannedMap *generics.Map[string, *BannedNode]
+	bannedMap   *generics.Map[string, *BannedNode]
+	scores      map[string]*bannedScore //misbehaviour points of the peers that are not banned yet
+	scoresMutex sync.Mutex
+}
+
+//the peers without an url are identified by the ip
+func GetAddressKey(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
 }
 
 func (this *BannedNodesType) IsBanned(urlStr string) bool {
-	if _, found := this.bannedMap.Load(urlStr); found {
+	if bannedNode, found := this.bannedMap.Load(urlStr); found && !bannedNode.IsExpired(time.Now()) {
 		return true
 	}
 	return false
 }
 
-func (this *BannedNodesType) Ban(url *url.URL, urlStr, message string, duration time.Duration) {
+func (this *BannedNodesType) IsAddressBanned(remoteAddr string) bool {
+	return this.IsBanned(GetAddressKey(remoteAddr))
+}
+
+func (this *BannedNodesType) Ban(url *url.URL, urlStr, message string, duration time.Duration) *BannedNode {
 	if urlStr == "" {
 		urlStr = url.String()
 	}
 	time := time.Now()
-	this.bannedMap.Store(urlStr, &BannedNode{
-		URL:        url,
+	bannedNode := &BannedNode{
+		URL:        urlStr,
 		Message:    message,
 		Timestamp:  time,
 		Expiration: time.Add(duration),
+	}
+	this.bannedMap.Store(urlStr, bannedNode)
+
+	if err := saveBannedNode(bannedNode); err != nil {
+		gui.GUI.Error("Error storing the banned node", urlStr, err)
+	}
+
+	return bannedNode
+}
+
+func (this *BannedNodesType) Unban(urlStr string) bool {
+	if _, found := this.bannedMap.LoadAndDelete(urlStr); !found {
+		return false
+	}
+	if err := deleteBannedNodes([]string{urlStr}); err != nil {
+		gui.GUI.Error("Error removing the banned node", urlStr, err)
+	}
+	return true
+}
+
+func (this *BannedNodesType) GetList() []*BannedNode {
+	list := []*BannedNode{}
+	this.bannedMap.Range(func(key string, bannedNode *BannedNode) bool {
+		list = append(list, bannedNode)
+		return true
+	})
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Timestamp.Before(list[j].Timestamp)
 	})
+	return list
+}
+
+//it returns true when the peer got banned because of too many misbehaviour points
+func (this *BannedNodesType) Penalize(key string, points int32, message string) bool {
+
+	if key == "" {
+		return false
+	}
+
+	this.scoresMutex.Lock()
+	score := this.scores[key]
+	if score == nil {
+		score = &bannedScore{}
+		this.scores[key] = score
+	}
+	score.score += points
+	score.timestamp = time.Now()
+
+	banned := score.score >= network_config.NETWORK_BAN_SCORE_THRESHOLD
+	if banned {
+		delete(this.scores, key)
+	}
+	this.scoresMutex.Unlock()
+
+	if banned {
+		this.Ban(nil, key, message, network_config.NETWORK_BAN_DURATION)
+		gui.GUI.Log("Peer banned", key, message)
+	}
+
+	return banned
+}
+
+func (this *BannedNodesType) sweepExpired() {
+
+	now := time.Now()
+
+	expired := []string{}
+	this.bannedMap.Range(func(key string, bannedNode *BannedNode) bool {
+		if bannedNode.IsExpired(now) {
+			expired = append(expired, key)
+		}
+		return true
+	})
+
+	for _, key := range expired {
+		this.bannedMap.Delete(key)
+	}
+
+	if len(expired) > 0 {
+		if err := deleteBannedNodes(expired); err != nil {
+			gui.GUI.Error("Error removing the expired banned nodes", err)
+		}
+	}
+
+	this.scoresMutex.Lock()
+	for key, score := range this.scores {
+		if now.Sub(score.timestamp) > network_config.NETWORK_BAN_SCORE_RESET {
+			delete(this.scores, key)
+		}
+	}
+	this.scoresMutex.Unlock()
+}
+
+//it must be called after the stores were opened
+func (this *BannedNodesType) Initialize() error {
+
+	list, err := loadBannedNodes()
+	if err != nil {
+		return err
+	}
+
+	for _, bannedNode := range list {
+		this.bannedMap.Store(bannedNode.URL, bannedNode)
+	}
+	this.sweepExpired()
+
+	recovery.SafeGo(func() {
+		for {
+			time.Sleep(network_config.NETWORK_BANNED_NODES_SWEEP_INTERVAL)
+			this.sweepExpired()
+		}
+	})
+
+	return nil
 }
 
 var BannedNodes *BannedNodesType
@@ -35,5 +173,6 @@ var BannedNodes *BannedNodesType
 func init() {
 	BannedNodes = &BannedNodesType{
 		bannedMap: &generics.Map[string, *BannedNode]{},
+		scores:    make(map[string]*bannedScore),
 	}
 }
@@ -0,0 +1,43 @@
+package banned_nodes
+
+import (
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+func saveBannedNode(bannedNode *BannedNode) error {
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		marshal, err := msgpack.Marshal(bannedNode)
+		if err != nil {
+			return
+		}
+
+		writer.Put("bannedNode:"+bannedNode.URL, marshal)
+		return
+	})
+}
+
+func deleteBannedNodes(urls []string) error {
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		for _, url := range urls {
+			writer.Delete("bannedNode:" + url)
+		}
+		return
+	})
+}
+
+func loadBannedNodes() (list []*BannedNode, err error) {
+	err = store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		return reader.Iterate("bannedNode:", "", false, func(key string, value []byte) (bool, error) {
+			bannedNode := &BannedNode{}
+			if err := msgpack.Unmarshal(value, bannedNode); err != nil {
+				return false, err
+			}
+			list = append(list, bannedNode)
+			return true, nil
+		})
+	})
+	return
+}
@@ -0,0 +1,84 @@
+package banned_nodes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/network_config"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+	"time"
+)
+
+func newTestBannedNodes(t *testing.T) *BannedNodesType {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+	assert.Nil(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("settings")
+	assert.Nil(t, err)
+	store.StoreSettings = &store.Store{"settings", true, db}
+
+	return &BannedNodesType{
+		bannedMap: &generics.Map[string, *BannedNode]{},
+		scores:    make(map[string]*bannedScore),
+	}
+}
+
+func TestBannedNodes_Penalize(t *testing.T) {
+
+	bannedNodes := newTestBannedNodes(t)
+
+	assert.False(t, bannedNodes.Penalize("", network_config.NETWORK_BAN_SCORE_THRESHOLD, "empty key"))
+
+	assert.False(t, bannedNodes.Penalize("1.2.3.4", network_config.NETWORK_BAN_SCORE_THRESHOLD-1, "invalid block"))
+	assert.False(t, bannedNodes.IsBanned("1.2.3.4"))
+
+	assert.True(t, bannedNodes.Penalize("1.2.3.4", 1, "invalid block"))
+	assert.True(t, bannedNodes.IsAddressBanned("1.2.3.4:8080"))
+	assert.False(t, bannedNodes.IsBanned("1.2.3.5"))
+
+	//the score starts again after the ban
+	assert.Nil(t, bannedNodes.scores["1.2.3.4"])
+
+	//the bans are persisted
+	list, err := loadBannedNodes()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(list))
+	assert.Equal(t, "1.2.3.4", list[0].URL)
+
+	assert.True(t, bannedNodes.Unban("1.2.3.4"))
+	assert.False(t, bannedNodes.IsBanned("1.2.3.4"))
+	list, err = loadBannedNodes()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(list))
+
+}
+
+func TestBannedNodes_SweepExpired(t *testing.T) {
+
+	bannedNodes := newTestBannedNodes(t)
+
+	bannedNodes.Ban(nil, "expired", "test", -time.Second)
+	bannedNodes.Ban(nil, "active", "test", time.Hour)
+	assert.False(t, bannedNodes.IsBanned("expired"))
+
+	assert.False(t, bannedNodes.Penalize("old", 1, "test"))
+	bannedNodes.scores["old"].timestamp = time.Now().Add(-network_config.NETWORK_BAN_SCORE_RESET - time.Second)
+	assert.False(t, bannedNodes.Penalize("recent", 1, "test"))
+
+	bannedNodes.sweepExpired()
+
+	assert.Equal(t, 1, len(bannedNodes.GetList()))
+	assert.True(t, bannedNodes.IsBanned("active"))
+	assert.Nil(t, bannedNodes.scores["old"])
+	assert.NotNil(t, bannedNodes.scores["recent"])
+
+	list, err := loadBannedNodes()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(list))
+
+}
@@ -2,6 +2,7 @@ package known_node
 
 import (
 	"sync/atomic"
+	"time"
 )
 
 type KnownNode struct {
@@ -11,11 +12,20 @@ type KnownNode struct {
 
 type KnownNodeScored struct {
 	KnownNode
-	Score int32 //use atomic
+	Score    int32 //use atomic
+	LastSeen int64 //unix time, use atomic
 }
 
 var KNOWN_KNODE_SCORE_MINIMUM = int32(-1000)
 
+func (self *KnownNodeScored) UpdateLastSeen() {
+	atomic.StoreInt64(&self.LastSeen, time.Now().Unix())
+}
+
+func (self *KnownNodeScored) IsStale(now, expiry int64) bool {
+	return !self.IsSeed && atomic.LoadInt64(&self.LastSeen)+expiry < now
+}
+
 func (self *KnownNodeScored) IncreaseScore(delta int32, isServer bool) (bool, int32) {
 
 	newScore := atomic.AddInt32(&self.Score, delta)
@@ -3,7 +3,9 @@ package known_nodes
 import (
 	"errors"
 	"math/rand"
+	"pandora-pay/gui"
 	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/recovery"
 	"pandora-pay/network/banned_nodes"
 	"pandora-pay/network/connected_nodes"
 	"pandora-pay/network/known_nodes/known_node"
@@ -11,6 +13,7 @@ import (
 	"pandora-pay/store/min_max_heap"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type KnownNodesType struct {
@@ -60,6 +63,7 @@ func (this *KnownNodesType) GetBestNotConnectedKnownNode() *known_node.KnownNode
 }
 
 func (this *KnownNodesType) IncreaseKnownNodeScore(knownNode *known_node.KnownNodeScored, delta int32, isServer bool) bool {
+	knownNode.UpdateLastSeen()
 	update, score := knownNode.IncreaseScore(delta, isServer)
 	if update {
 		this.knownNotConnectedMaxHeapMutex.Lock()
@@ -86,12 +90,14 @@ func (this *KnownNodesType) DecreaseKnownNodeScore(knownNode *known_node.KnownNo
 }
 
 func (this *KnownNodesType) MarkKnownNodeConnected(knownNode *known_node.KnownNodeScored) {
+	knownNode.UpdateLastSeen()
 	this.knownNotConnectedMaxHeapMutex.Lock()
 	defer this.knownNotConnectedMaxHeapMutex.Unlock()
 	this.knownNotConnectedMaxHeap.DeleteByKey([]byte(knownNode.URL))
 }
 
 func (this *KnownNodesType) MarkKnownNodeDisconnected(knownNode *known_node.KnownNodeScored) {
+	knownNode.UpdateLastSeen()
 	this.knownNotConnectedMaxHeapMutex.Lock()
 	defer this.knownNotConnectedMaxHeapMutex.Unlock()
 	this.knownNotConnectedMaxHeap.Update(float64(atomic.LoadInt32(&knownNode.Score)), []byte(knownNode.URL))
@@ -116,7 +122,8 @@ func (this *KnownNodesType) AddKnownNode(url string, isSeed bool) (*known_node.K
 			URL:    url,
 			IsSeed: isSeed,
 		},
-		Score: 0,
+		Score:    0,
+		LastSeen: time.Now().Unix(),
 	}
 
 	if _, exists := this.knownMap.LoadOrStore(url, knownNode); exists {
@@ -197,7 +204,8 @@ func (this *KnownNodesType) Reset(urls []string, isSeed bool) (err error) {
 				URL:    url,
 				IsSeed: isSeed,
 			},
-			Score: 0,
+			Score:    0,
+			LastSeen: time.Now().Unix(),
 		}
 
 		this.knownMap.LoadOrStore(url, knownNode)
@@ -212,6 +220,76 @@ func (this *KnownNodesType) Reset(urls []string, isSeed bool) (err error) {
 	return
 }
 
+func (this *KnownNodesType) restoreKnownNode(stored *knownNodeStored) (err error) {
+
+	knownNode, exists := this.knownMap.Load(stored.URL)
+	if !exists {
+		if knownNode, err = this.AddKnownNode(stored.URL, false); err != nil {
+			return
+		}
+	}
+
+	atomic.StoreInt32(&knownNode.Score, stored.Score)
+	atomic.StoreInt64(&knownNode.LastSeen, stored.LastSeen)
+
+	this.knownNotConnectedMaxHeapMutex.Lock()
+	defer this.knownNotConnectedMaxHeapMutex.Unlock()
+	return this.knownNotConnectedMaxHeap.Update(float64(stored.Score), []byte(stored.URL))
+}
+
+//the stale nodes are removed before saving
+func (this *KnownNodesType) saveKnownNodes() error {
+
+	now := time.Now().Unix()
+	expiry := int64(network_config.NETWORK_KNOWN_NODES_EXPIRY / time.Second)
+
+	list := make([]*knownNodeStored, 0)
+	for _, knownNode := range this.GetList() {
+		if knownNode.IsStale(now, expiry) {
+			this.RemoveKnownNode(knownNode)
+			continue
+		}
+		list = append(list, &knownNodeStored{knownNode.URL, atomic.LoadInt32(&knownNode.Score), atomic.LoadInt64(&knownNode.LastSeen)})
+	}
+
+	return saveKnownNodes(list)
+}
+
+//it must be called after the seeds were added
+func (this *KnownNodesType) Initialize() error {
+
+	list, err := loadKnownNodes()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	expiry := int64(network_config.NETWORK_KNOWN_NODES_EXPIRY / time.Second)
+
+	for _, stored := range list {
+		if atomic.LoadInt32(&this.knownCount) > network_config.NETWORK_KNOWN_NODES_LIMIT {
+			break
+		}
+		if stored.LastSeen+expiry < now || banned_nodes.BannedNodes.IsBanned(stored.URL) {
+			continue
+		}
+		if err = this.restoreKnownNode(stored); err != nil {
+			gui.GUI.Error("Error restoring the known node", stored.URL, err)
+		}
+	}
+
+	recovery.SafeGo(func() {
+		for {
+			time.Sleep(network_config.NETWORK_KNOWN_NODES_SAVE_INTERVAL)
+			if err := this.saveKnownNodes(); err != nil {
+				gui.GUI.Error("Error saving the known nodes", err)
+			}
+		}
+	})
+
+	return nil
+}
+
 func init() {
 	KnownNodes = &KnownNodesType{
 		&generics.Map[string, *known_node.KnownNodeScored]{},
@@ -0,0 +1,47 @@
+package known_nodes
+
+import (
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+type knownNodeStored struct {
+	URL      string `msgpack:"url"`
+	Score    int32  `msgpack:"score"`
+	LastSeen int64  `msgpack:"lastSeen"`
+}
+
+//the previous list is replaced entirely
+func saveKnownNodes(list []*knownNodeStored) error {
+	return store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if err = store_db_interface.DeleteRange(writer, "knownNode:", "", ""); err != nil {
+			return
+		}
+
+		for _, knownNode := range list {
+			var marshal []byte
+			if marshal, err = msgpack.Marshal(knownNode); err != nil {
+				return
+			}
+			writer.Put("knownNode:"+knownNode.URL, marshal)
+		}
+
+		return
+	})
+}
+
+func loadKnownNodes() (list []*knownNodeStored, err error) {
+	err = store.StoreSettings.DB.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		return reader.Iterate("knownNode:", "", false, func(key string, value []byte) (bool, error) {
+			knownNode := &knownNodeStored{}
+			if err := msgpack.Unmarshal(value, knownNode); err != nil {
+				return false, err
+			}
+			list = append(list, knownNode)
+			return true, nil
+		})
+	})
+	return
+}
@@ -0,0 +1,92 @@
+package known_nodes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/gui"
+	"pandora-pay/gui/gui_non_interactive"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/banned_nodes"
+	"pandora-pay/network/known_nodes/known_node"
+	"pandora-pay/network/network_config"
+	"pandora-pay/store"
+	"pandora-pay/store/min_max_heap"
+	"pandora-pay/store/store_db/store_db_memory"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestKnownNodes() *KnownNodesType {
+	return &KnownNodesType{
+		&generics.Map[string, *known_node.KnownNodeScored]{},
+		make([]*known_node.KnownNodeScored, 0),
+		sync.RWMutex{},
+		min_max_heap.NewMaxMemoryHeap(),
+		sync.RWMutex{},
+		0,
+	}
+}
+
+func TestKnownNodes_SaveAndRestore(t *testing.T) {
+
+	var err error
+	gui.GUI, err = gui_non_interactive.CreateGUINonInteractive()
+	assert.Nil(t, err)
+
+	db, err := store_db_memory.CreateStoreDBMemory("settings")
+	assert.Nil(t, err)
+	store.StoreSettings = &store.Store{"settings", true, db}
+
+	now := time.Now().Unix()
+	expiry := int64(network_config.NETWORK_KNOWN_NODES_EXPIRY / time.Second)
+
+	knownNodes := newTestKnownNodes()
+	for _, url := range []string{"ws://a", "ws://b", "ws://stale"} {
+		_, err = knownNodes.AddKnownNode(url, false)
+		assert.Nil(t, err)
+	}
+	a, _ := knownNodes.knownMap.Load("ws://a")
+	a.Score = 30
+	a.LastSeen = now - 100
+	b, _ := knownNodes.knownMap.Load("ws://b")
+	b.Score = -5
+	stale, _ := knownNodes.knownMap.Load("ws://stale")
+	stale.LastSeen = now - expiry - 10
+
+	//the stale nodes are removed and not saved
+	assert.Nil(t, knownNodes.saveKnownNodes())
+	assert.Equal(t, 2, len(knownNodes.GetList()))
+
+	list, err := loadKnownNodes()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(list))
+
+	//the expired and the banned entries are skipped when loading
+	list = append(list, &knownNodeStored{"ws://expired", 10, now - expiry - 10}, &knownNodeStored{"ws://banned", 10, now})
+	assert.Nil(t, saveKnownNodes(list))
+
+	banned_nodes.BannedNodes.Ban(nil, "ws://banned", "test", time.Hour)
+	defer banned_nodes.BannedNodes.Unban("ws://banned")
+
+	restored := newTestKnownNodes()
+	assert.Nil(t, restored.Initialize())
+	assert.Equal(t, 2, len(restored.GetList()))
+
+	a2, ok := restored.knownMap.Load("ws://a")
+	assert.True(t, ok)
+	assert.Equal(t, int32(30), a2.Score)
+	assert.Equal(t, now-100, a2.LastSeen)
+
+	b2, ok := restored.knownMap.Load("ws://b")
+	assert.True(t, ok)
+	assert.Equal(t, int32(-5), b2.Score)
+	assert.Equal(t, b.LastSeen, b2.LastSeen)
+
+	_, ok = restored.knownMap.Load("ws://expired")
+	assert.False(t, ok)
+	_, ok = restored.knownMap.Load("ws://banned")
+	assert.False(t, ok)
+
+	//the best not connected node is the one with the restored score
+	assert.Equal(t, "ws://a", restored.GetBestNotConnectedKnownNode().URL)
+}
@@ -6,8 +6,11 @@ import (
 	"pandora-pay/config"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/mempool"
+	"pandora-pay/network/banned_nodes"
 	"pandora-pay/network/connected_nodes"
 	"pandora-pay/network/known_nodes"
+	"pandora-pay/network/node_identity"
+	"pandora-pay/network/rate_limiter"
 	"pandora-pay/network/server/node_tcp"
 	"pandora-pay/network/websocks"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
@@ -94,6 +97,16 @@ func SendJSONAwaitAnswer[T any](name []byte, data any, ctxParent context.Context
 
 func NewNetwork(settings *settings.Settings, chain *blockchain.Blockchain, mempool *mempool.Mempool, wallet *wallet.Wallet) error {
 
+	if err := banned_nodes.BannedNodes.Initialize(); err != nil {
+		return err
+	}
+
+	if err := node_identity.NodeIdentity.Initialize(); err != nil {
+		return err
+	}
+
+	rate_limiter.RateLimiter.Initialize()
+
 	list := make([]string, len(config.NETWORK_SELECTED_SEEDS))
 	for i, seed := range config.NETWORK_SELECTED_SEEDS {
 		list[i] = seed.Url
@@ -101,6 +114,9 @@ func NewNetwork(settings *settings.Settings, chain *blockchain.Blockchain, mempo
 	if err := known_nodes.KnownNodes.Reset(list, true); err != nil {
 		return err
 	}
+	if err := known_nodes.KnownNodes.Initialize(); err != nil {
+		return err
+	}
 
 	if err := node_tcp.NewTcpServer(settings, chain, mempool, wallet); err != nil {
 		return err
@@ -1,6 +1,7 @@
 package network_config
 
 import (
+	"errors"
 	"pandora-pay/config"
 	"pandora-pay/config/arguments"
 	"pandora-pay/network/network_config/network_config_auth"
@@ -13,10 +14,16 @@ var (
 	WEBSOCKETS_NETWORK_SERVER_MAX        = int64(500)
 	NETWORK_ADDRESS_URL_STRING           string
 	NETWORK_WEBSOCKET_ADDRESS_URL_STRING string
+	NETWORK_ALLOWLIST_PATH               string
 	NETWORK_KNOWN_NODES_LIMIT            int32 = 5000
 	NETWORK_KNOWN_NODES_LIST_RETURN            = 100
 	NETWORK_ENABLE_SUBSCRIPTIONS               = false
 	NETWORK_CONNECTIONS_READY_THRESHOLD        = int64(1)
+	NETWORK_PERMISSIONED                       = false
+	NETWORK_RATE_LIMIT_IP                      = uint64(100) //requests per second
+	NETWORK_RATE_LIMIT_CONNECTION              = uint64(50)
+	NETWORK_RATE_LIMIT_EXPENSIVE               = uint64(5)
+	NETWORK_RATE_LIMIT_CONSENSUS               = uint64(500)
 	STATIC_FILES                               = map[string]string{}
 )
 
@@ -31,6 +38,21 @@ const (
 	WEBSOCKETS_TIMEOUT                            = 15 * time.Second //seconds
 )
 
+const (
+	NETWORK_BAN_SCORE_THRESHOLD          = int32(100)
+	NETWORK_BAN_SCORE_RESET              = 1 * time.Hour
+	NETWORK_BAN_DURATION                 = 24 * time.Hour
+	NETWORK_BANNED_NODES_SWEEP_INTERVAL  = 1 * time.Minute
+	NETWORK_KNOWN_NODES_SAVE_INTERVAL    = 5 * time.Minute
+	NETWORK_KNOWN_NODES_EXPIRY           = 7 * 24 * time.Hour
+	NETWORK_PENALTY_MALFORMED_MESSAGE    = int32(10)
+	NETWORK_PENALTY_INVALID_CHAIN_UPDATE = int32(20)
+	NETWORK_PENALTY_INVALID_BLOCK        = int32(50)
+	NETWORK_ALLOWLIST_RELOAD_INTERVAL    = 10 * time.Second
+	NETWORK_RATE_LIMIT_BURST             = float64(5) //seconds of requests that can be done at once
+	NETWORK_RATE_LIMIT_SWEEP_INTERVAL    = 1 * time.Minute
+)
+
 func InitConfig() (err error) {
 
 	if arguments.Arguments["--tcp-max-clients"] != nil {
@@ -51,6 +73,43 @@ func InitConfig() (err error) {
 		}
 	}
 
+	if arguments.Arguments["--rate-limit-ip"] != nil {
+		if NETWORK_RATE_LIMIT_IP, err = strconv.ParseUint(arguments.Arguments["--rate-limit-ip"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if arguments.Arguments["--rate-limit-connection"] != nil {
+		if NETWORK_RATE_LIMIT_CONNECTION, err = strconv.ParseUint(arguments.Arguments["--rate-limit-connection"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if arguments.Arguments["--rate-limit-expensive"] != nil {
+		if NETWORK_RATE_LIMIT_EXPENSIVE, err = strconv.ParseUint(arguments.Arguments["--rate-limit-expensive"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if arguments.Arguments["--rate-limit-consensus"] != nil {
+		if NETWORK_RATE_LIMIT_CONSENSUS, err = strconv.ParseUint(arguments.Arguments["--rate-limit-consensus"].(string), 10, 64); err != nil {
+			return
+		}
+	}
+
+	if arguments.Arguments["--network-permissioned"] == true {
+		NETWORK_PERMISSIONED = true
+		if arguments.Arguments["--network-allowlist"] == nil {
+			return errors.New("--network-allowlist is required for a permissioned network")
+		}
+		NETWORK_ALLOWLIST_PATH = arguments.Arguments["--network-allowlist"].(string)
+	}
+
+	//the headers only prove the work of the kernel hash. The stake behind it is verified only by the full nodes, so the headers are accepted only from the allowed nodes
+	if config.NODE_CONSENSUS_APP_HEADERS && !NETWORK_PERMISSIONED {
+		return errors.New("--node-consensus=app-headers requires --network-permissioned")
+	}
+
 	if config.NETWORK_SELECTED == config.TEST_NET_NETWORK_BYTE || config.NETWORK_SELECTED == config.DEV_NET_NETWORK_BYTE {
 
 		if arguments.Arguments["--hcaptcha-secret"] != nil {
@@ -1,18 +1,65 @@
 package network_config_auth
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"pandora-pay/config/arguments"
+	"pandora-pay/cryptography"
+	"strconv"
+	"time"
 )
 
+type ConfigAuthRole string
+
+const (
+	AUTH_ROLE_READ    ConfigAuthRole = "read" //read only wallet
+	AUTH_ROLE_SPENDER ConfigAuthRole = "spender"
+	AUTH_ROLE_ADMIN   ConfigAuthRole = "admin"
+)
+
+var authRolesLevels = map[ConfigAuthRole]int{
+	AUTH_ROLE_READ:    1,
+	AUTH_ROLE_SPENDER: 2,
+	AUTH_ROLE_ADMIN:   3,
+}
+
+//every role includes the permissions of the lower roles
+func (role ConfigAuthRole) Includes(required ConfigAuthRole) bool {
+	level := authRolesLevels[role]
+	return level > 0 && level >= authRolesLevels[required]
+}
+
+//the users without a role are admins
+func initRole(role *ConfigAuthRole, username string) error {
+	if *role == "" {
+		*role = AUTH_ROLE_ADMIN
+	}
+	if authRolesLevels[*role] == 0 {
+		return errors.New("Invalid role for the auth user " + username)
+	}
+	return nil
+}
+
 type ConfigAuth struct {
-	Username string `json:"user" msgpack:"user"`
-	Password string `json:"pass"  msgpack:"pass"`
+	Username string         `json:"user" msgpack:"user"`
+	Password string         `json:"pass"  msgpack:"pass"`
+	Role     ConfigAuthRole `json:"role,omitempty" msgpack:"role,omitempty"`
+}
+
+//the user proves the key by signing a challenge. No secret is given to the node
+type ConfigAuthKey struct {
+	Username  string         `json:"user" msgpack:"user"`
+	PublicKey string         `json:"publicKey" msgpack:"publicKey"` //hex
+	Role      ConfigAuthRole `json:"role,omitempty" msgpack:"role,omitempty"`
 }
 
 var (
-	CONFIG_AUTH_USERS_LIST []*ConfigAuth
-	CONFIG_AUTH_USERS_MAP  map[string]*ConfigAuth
+	CONFIG_AUTH_USERS_LIST     []*ConfigAuth
+	CONFIG_AUTH_USERS_MAP      map[string]*ConfigAuth
+	CONFIG_AUTH_KEYS_LIST      []*ConfigAuthKey
+	CONFIG_AUTH_KEYS_MAP       map[string]*ConfigAuthKey //by the public key bytes
+	CONFIG_AUTH_SESSION_EXPIRY = 1 * time.Hour
 )
 
 func InitConfig() (err error) {
@@ -25,8 +72,40 @@ func InitConfig() (err error) {
 
 	CONFIG_AUTH_USERS_MAP = map[string]*ConfigAuth{}
 	for _, auth := range CONFIG_AUTH_USERS_LIST {
+		if err = initRole(&auth.Role, auth.Username); err != nil {
+			return
+		}
 		CONFIG_AUTH_USERS_MAP[auth.Username] = auth
 	}
 
+	if str := arguments.Arguments["--auth-keys"]; str != nil {
+		if err = json.Unmarshal([]byte(str.(string)), &CONFIG_AUTH_KEYS_LIST); err != nil {
+			return
+		}
+	}
+
+	CONFIG_AUTH_KEYS_MAP = map[string]*ConfigAuthKey{}
+	for _, auth := range CONFIG_AUTH_KEYS_LIST {
+		if err = initRole(&auth.Role, auth.Username); err != nil {
+			return
+		}
+		publicKey, err := hex.DecodeString(auth.PublicKey)
+		if err != nil || len(publicKey) != cryptography.PublicKeySize {
+			return errors.New("Invalid public key for the auth user " + auth.Username)
+		}
+		CONFIG_AUTH_KEYS_MAP[string(publicKey)] = auth
+	}
+
+	if str := arguments.Arguments["--auth-session-expiry"]; str != nil {
+		var seconds uint64
+		if seconds, err = strconv.ParseUint(str.(string), 10, 64); err != nil {
+			return
+		}
+		if seconds == 0 {
+			return errors.New("--auth-session-expiry must be greater than zero")
+		}
+		CONFIG_AUTH_SESSION_EXPIRY = time.Duration(seconds) * time.Second
+	}
+
 	return
 }
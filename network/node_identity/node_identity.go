@@ -0,0 +1,192 @@
+package node_identity
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"pandora-pay/addresses"
+	"pandora-pay/cryptography"
+	"pandora-pay/cryptography/crypto"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/helpers/recovery"
+	"pandora-pay/network/connected_nodes"
+	"pandora-pay/network/network_config"
+	"strings"
+	"time"
+)
+
+type NodeIdentityType struct {
+	privateKey       *addresses.PrivateKey
+	PublicKey        []byte
+	allowlist        *generics.Value[map[string]bool]
+	allowlistModTime time.Time
+}
+
+//the challenge is hashed with a prefix to never sign a message that has a different meaning. The verifier key and the side of the signer bind the signature to the verifier.
+//The channel binding is exported from the TLS session of the connection, so a relay between two nodes has a different value on each side and the signature can't be forwarded
+func getHandshakeMessage(challenge, verifierPublicKey, channelBinding []byte, signerIsServer bool) []byte {
+	side := "client"
+	if signerIsServer {
+		side = "server"
+	}
+	message := append([]byte("nodeHandshake"), verifierPublicKey...)
+	message = append(message, side...)
+	message = append(message, channelBinding...)
+	return cryptography.SHA3(append(message, challenge...))
+}
+
+//the request contains the challenge followed by the public key of the verifier
+func GetHandshakeRequest(challenge, verifierPublicKey []byte) []byte {
+	return append(helpers.CloneBytes(challenge), verifierPublicKey...)
+}
+
+func (this *NodeIdentityType) SignHandshake(request, channelBinding []byte, signerIsServer bool) ([]byte, error) {
+	if this.privateKey == nil {
+		return nil, errors.New("Node identity is not initialized")
+	}
+	if len(request) != cryptography.HashSize+cryptography.PublicKeySize {
+		return nil, errors.New("Invalid handshake challenge")
+	}
+	if len(channelBinding) != cryptography.HashSize {
+		return nil, errors.New("Invalid channel binding")
+	}
+	return this.privateKey.Sign(getHandshakeMessage(request[:cryptography.HashSize], request[cryptography.HashSize:], channelBinding, signerIsServer))
+}
+
+func (this *NodeIdentityType) IsAllowed(publicKey []byte) bool {
+	return this.allowlist.Load()[string(publicKey)]
+}
+
+//it is verified only when the network is permissioned. The signer is on the other side of the connection
+func (this *NodeIdentityType) VerifyHandshake(challenge, channelBinding, publicKey, signature []byte, verifierIsServer bool) error {
+
+	if !network_config.NETWORK_PERMISSIONED {
+		return nil
+	}
+
+	if len(publicKey) != cryptography.PublicKeySize || len(signature) != cryptography.SignatureSize {
+		return errors.New("Handshake is not signed")
+	}
+	if len(channelBinding) != cryptography.HashSize {
+		return errors.New("Invalid channel binding")
+	}
+	if !crypto.VerifySignature(getHandshakeMessage(challenge, this.PublicKey, channelBinding, !verifierIsServer), signature, publicKey) {
+		return errors.New("Handshake signature is invalid")
+	}
+	if !this.IsAllowed(publicKey) {
+		return errors.New("Node key is not in the allowlist")
+	}
+
+	return nil
+}
+
+//one hex public key per line. Empty lines and the lines starting with # are ignored
+func readAllowlist(path string) (map[string]bool, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	allowlist := make(map[string]bool)
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		publicKey, err := hex.DecodeString(text)
+		if err != nil || len(publicKey) != cryptography.PublicKeySize {
+			return nil, fmt.Errorf("Invalid node public key at line %d", line)
+		}
+		allowlist[string(publicKey)] = true
+	}
+
+	return allowlist, scanner.Err()
+}
+
+//it returns true when the file was changed and loaded again
+func (this *NodeIdentityType) reloadAllowlist() (bool, error) {
+
+	stat, err := os.Stat(network_config.NETWORK_ALLOWLIST_PATH)
+	if err != nil {
+		return false, err
+	}
+	if stat.ModTime().Equal(this.allowlistModTime) {
+		return false, nil
+	}
+
+	allowlist, err := readAllowlist(network_config.NETWORK_ALLOWLIST_PATH)
+	if err != nil {
+		return false, err
+	}
+
+	this.allowlist.Store(allowlist)
+	this.allowlistModTime = stat.ModTime()
+
+	return true, nil
+}
+
+//the peers that were removed from the allowlist are disconnected
+func (this *NodeIdentityType) closeNotAllowedConnections() {
+	for _, conn := range connected_nodes.ConnectedNodes.AllList.Get() {
+		if conn.Handshake != nil && !this.IsAllowed(conn.Handshake.NodePublicKey) {
+			conn.Close()
+		}
+	}
+}
+
+//it must be called after the stores were opened
+func (this *NodeIdentityType) Initialize() (err error) {
+
+	if this.privateKey, err = loadOrCreatePrivateKey(); err != nil {
+		return
+	}
+	this.PublicKey = this.privateKey.GeneratePublicKey()
+
+	gui.GUI.Log("Node public key", hex.EncodeToString(this.PublicKey))
+
+	if !network_config.NETWORK_PERMISSIONED {
+		return
+	}
+
+	if _, err = this.reloadAllowlist(); err != nil {
+		return
+	}
+	gui.GUI.Log("Permissioned network. Allowed nodes:", len(this.allowlist.Load()))
+
+	recovery.SafeGo(func() {
+		for {
+			time.Sleep(network_config.NETWORK_ALLOWLIST_RELOAD_INTERVAL)
+
+			changed, err := this.reloadAllowlist()
+			if err != nil {
+				gui.GUI.Error("Error reloading the node allowlist", err)
+				continue
+			}
+			if changed {
+				gui.GUI.Log("Node allowlist reloaded. Allowed nodes:", len(this.allowlist.Load()))
+				this.closeNotAllowedConnections()
+			}
+		}
+	})
+
+	return
+}
+
+var NodeIdentity *NodeIdentityType
+
+func init() {
+	NodeIdentity = &NodeIdentityType{
+		allowlist: &generics.Value[map[string]bool]{},
+	}
+	NodeIdentity.allowlist.Store(map[string]bool{})
+}
@@ -0,0 +1,93 @@
+//go:build !js
+// +build !js
+
+package node_identity
+
+import (
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"pandora-pay/cryptography"
+	"pandora-pay/network/network_config"
+	"pandora-pay/network/websocks/websock"
+	"strings"
+	"testing"
+)
+
+func dialTestServer(t *testing.T, server *httptest.Server) *websock.Conn {
+	dialer := &websocket.Dialer{TLSClientConfig: server.Client().Transport.(*http.Transport).TLSClientConfig}
+	c, _, err := dialer.Dial("wss"+strings.TrimPrefix(server.URL, "https"), nil)
+	assert.Nil(t, err)
+	return &websock.Conn{c}
+}
+
+//a relay has a TLS session with each node, so the handshake signed for one session is rejected on the other
+func TestNodeIdentity_HandshakeRelay(t *testing.T) {
+
+	network_config.NETWORK_PERMISSIONED = true
+	defer func() {
+		network_config.NETWORK_PERMISSIONED = false
+	}()
+
+	signer := newTestNodeIdentity()
+	verifier := newTestNodeIdentity(signer)
+
+	challenge := cryptography.RandomHash()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websock.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		channelBinding, err := conn.GetChannelBinding()
+		assert.Nil(t, err)
+		signature, err := signer.SignHandshake(GetHandshakeRequest(challenge, verifier.PublicKey), channelBinding, true)
+		assert.Nil(t, err)
+
+		assert.Nil(t, conn.WriteMessage(websock.BinaryMessage, signature))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	//the relay forwards the signature of the server
+	relay := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websock.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		upstream := dialTestServer(t, server)
+		defer upstream.Close()
+
+		_, signature, err := upstream.ReadMessage()
+		assert.Nil(t, err)
+
+		assert.Nil(t, conn.WriteMessage(websock.BinaryMessage, signature))
+		conn.ReadMessage()
+	}))
+	defer relay.Close()
+
+	for _, test := range []struct {
+		server *httptest.Server
+		valid  bool
+	}{{server, true}, {relay, false}} {
+
+		conn := dialTestServer(t, test.server)
+
+		_, signature, err := conn.ReadMessage()
+		assert.Nil(t, err)
+
+		channelBinding, err := conn.GetChannelBinding()
+		assert.Nil(t, err)
+
+		err = verifier.VerifyHandshake(challenge, channelBinding, signer.PublicKey, signature, false)
+		assert.Equal(t, test.valid, err == nil)
+
+		conn.Close()
+	}
+
+}
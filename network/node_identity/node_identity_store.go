@@ -0,0 +1,24 @@
+package node_identity
+
+import (
+	"pandora-pay/addresses"
+	"pandora-pay/helpers"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+//the key is created only once and it is kept across restarts
+func loadOrCreatePrivateKey() (privateKey *addresses.PrivateKey, err error) {
+	err = store.StoreSettings.DB.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		if data := writer.Get("nodeIdentity"); data != nil {
+			privateKey, err = addresses.NewPrivateKey(helpers.CloneBytes(data))
+			return
+		}
+
+		privateKey = addresses.GenerateNewPrivateKey()
+		writer.Put("nodeIdentity", privateKey.Key)
+		return
+	})
+	return
+}
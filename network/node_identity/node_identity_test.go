@@ -0,0 +1,87 @@
+package node_identity
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"pandora-pay/addresses"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers/generics"
+	"pandora-pay/network/network_config"
+	"path/filepath"
+	"testing"
+)
+
+func newTestNodeIdentity(allowed ...*NodeIdentityType) *NodeIdentityType {
+	privateKey := addresses.GenerateNewPrivateKey()
+	identity := &NodeIdentityType{
+		privateKey: privateKey,
+		PublicKey:  privateKey.GeneratePublicKey(),
+		allowlist:  &generics.Value[map[string]bool]{},
+	}
+	allowlist := map[string]bool{}
+	for _, node := range allowed {
+		allowlist[string(node.PublicKey)] = true
+	}
+	identity.allowlist.Store(allowlist)
+	return identity
+}
+
+func TestNodeIdentity_Handshake(t *testing.T) {
+
+	network_config.NETWORK_PERMISSIONED = true
+	defer func() {
+		network_config.NETWORK_PERMISSIONED = false
+	}()
+
+	signer := newTestNodeIdentity()
+	verifier := newTestNodeIdentity(signer)
+	other := newTestNodeIdentity(signer)
+
+	challenge, channelBinding := cryptography.RandomHash(), cryptography.RandomHash()
+
+	//the signer is the server of the connection
+	signature, err := signer.SignHandshake(GetHandshakeRequest(challenge, verifier.PublicKey), channelBinding, true)
+	assert.Nil(t, err)
+	assert.Nil(t, verifier.VerifyHandshake(challenge, channelBinding, signer.PublicKey, signature, false))
+
+	//the signature can't be used by another verifier, for the other side or for another TLS session
+	assert.NotNil(t, other.VerifyHandshake(challenge, channelBinding, signer.PublicKey, signature, false))
+	assert.NotNil(t, verifier.VerifyHandshake(challenge, channelBinding, signer.PublicKey, signature, true))
+	assert.NotNil(t, verifier.VerifyHandshake(cryptography.RandomHash(), channelBinding, signer.PublicKey, signature, false))
+	assert.NotNil(t, verifier.VerifyHandshake(challenge, cryptography.RandomHash(), signer.PublicKey, signature, false))
+	assert.NotNil(t, verifier.VerifyHandshake(challenge, nil, signer.PublicKey, signature, false))
+
+	//the keys that are not in the allowlist are rejected
+	signature, err = other.SignHandshake(GetHandshakeRequest(challenge, verifier.PublicKey), channelBinding, true)
+	assert.Nil(t, err)
+	assert.NotNil(t, verifier.VerifyHandshake(challenge, channelBinding, other.PublicKey, signature, false))
+
+	assert.NotNil(t, verifier.VerifyHandshake(challenge, channelBinding, signer.PublicKey, nil, false))
+
+	_, err = signer.SignHandshake(challenge, channelBinding, true)
+	assert.NotNil(t, err)
+	_, err = signer.SignHandshake(GetHandshakeRequest(challenge, verifier.PublicKey), nil, true)
+	assert.NotNil(t, err)
+
+	//nothing is verified when the network is not permissioned
+	network_config.NETWORK_PERMISSIONED = false
+	assert.Nil(t, verifier.VerifyHandshake(challenge, nil, nil, nil, false))
+
+}
+
+func TestNodeIdentity_ReadAllowlist(t *testing.T) {
+
+	identity := newTestNodeIdentity()
+	path := filepath.Join(t.TempDir(), "allowlist")
+
+	assert.Nil(t, os.WriteFile(path, []byte("# nodes\n\n"+hex.EncodeToString(identity.PublicKey)+"\n"), 0600))
+	allowlist, err := readAllowlist(path)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{string(identity.PublicKey): true}, allowlist)
+
+	assert.Nil(t, os.WriteFile(path, []byte("invalid\n"), 0600))
+	_, err = readAllowlist(path)
+	assert.NotNil(t, err)
+
+}
@@ -0,0 +1,209 @@
+package rate_limiter
+
+import (
+	"fmt"
+	"math"
+	"pandora-pay/helpers/recovery"
+	"pandora-pay/network/network_config"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"sync"
+	"time"
+)
+
+//the methods that are costly to answer have a second smaller budget
+var EXPENSIVE_METHODS = map[string]bool{
+	"accounts/by-keys":        true,
+	"accounts/keys-by-index":  true,
+	"tx-preview":              true,
+	"tx/proof":                true,
+	"tx/simulate":             true,
+	"document/proof":          true,
+	"mempool/new-txs":         true,
+	"wallet/decrypt-tx":       true,
+	"wallet/private-transfer": true,
+}
+
+//the methods used by the nodes to sync. After the handshake they have their own budget, so the api requests can't throttle the consensus
+var CONSENSUS_METHODS = map[string]bool{
+	"block":             true,
+	"block-hash":        true,
+	"block-miss-txs":    true,
+	"chain-update":      true,
+	"get-chain":         true,
+	"get-headers":       true,
+	"mempool/new-tx-id": true,
+	"tx-raw":            true,
+}
+
+//the unknown routes are limited under the same method, so the clients can't grow the throttled methods with random names
+const UNKNOWN_METHOD = "unknown"
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+//the bucket is refilled with the time passed since the last request
+func (bucket *tokenBucket) refill(now time.Time, rate float64) {
+	burst := rate * network_config.NETWORK_RATE_LIMIT_BURST
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.last).Seconds()*rate)
+	bucket.last = now
+}
+
+func (bucket *tokenBucket) take(now time.Time, rate float64) bool {
+	bucket.refill(now, rate)
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens -= 1
+	return true
+}
+
+type buckets[K comparable] map[K]*tokenBucket
+
+//a rate of zero disables the limit
+func (this buckets[K]) take(key K, now time.Time, rate uint64) bool {
+	if rate == 0 {
+		return true
+	}
+	bucket := this[key]
+	if bucket == nil { //it is filled by the first refill
+		bucket = &tokenBucket{}
+		this[key] = bucket
+	}
+	return bucket.take(now, float64(rate))
+}
+
+//the full buckets are the same as the missing ones
+func (this buckets[K]) sweep(now time.Time, rate uint64) {
+	for key, bucket := range this {
+		bucket.refill(now, float64(rate))
+		if bucket.tokens >= float64(rate)*network_config.NETWORK_RATE_LIMIT_BURST {
+			delete(this, key)
+		}
+	}
+}
+
+type RateLimiterType struct {
+	ips              buckets[string]
+	ipsExpensive     buckets[string]
+	conns            buckets[advanced_connection_types.UUID]
+	connsExpensive   buckets[advanced_connection_types.UUID]
+	connsConsensus   buckets[advanced_connection_types.UUID]
+	allowed          uint64
+	throttled        uint64
+	throttledMethods map[string]uint64
+	lock             *sync.Mutex
+}
+
+func (this *RateLimiterType) result(method string, allowed bool) error {
+	if allowed {
+		this.allowed++
+		return nil
+	}
+	this.throttled++
+	this.throttledMethods[method]++
+	return fmt.Errorf("Rate limit exceeded for %s. Retry later", method)
+}
+
+func (this *RateLimiterType) takeIP(ip, method string, now time.Time) bool {
+	if EXPENSIVE_METHODS[method] && !this.ipsExpensive.take(ip, now, network_config.NETWORK_RATE_LIMIT_EXPENSIVE) {
+		return false
+	}
+	return this.ips.take(ip, now, network_config.NETWORK_RATE_LIMIT_IP)
+}
+
+//ip is the remote address for the http requests
+func (this *RateLimiterType) AllowIP(ip, method string) error {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	return this.result(method, this.takeIP(ip, method, time.Now()))
+}
+
+//the connection has its own budget and it shares the budget of the ip with the other connections and the http requests
+func (this *RateLimiterType) AllowConnection(uuid advanced_connection_types.UUID, ip, method string, handshaked bool) error {
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	now := time.Now()
+
+	if handshaked && CONSENSUS_METHODS[method] {
+		return this.result(method, this.connsConsensus.take(uuid, now, network_config.NETWORK_RATE_LIMIT_CONSENSUS))
+	}
+
+	if EXPENSIVE_METHODS[method] && !this.connsExpensive.take(uuid, now, network_config.NETWORK_RATE_LIMIT_EXPENSIVE) {
+		return this.result(method, false)
+	}
+	if !this.conns.take(uuid, now, network_config.NETWORK_RATE_LIMIT_CONNECTION) {
+		return this.result(method, false)
+	}
+
+	return this.result(method, this.takeIP(ip, method, now))
+}
+
+func (this *RateLimiterType) RemoveConnection(uuid advanced_connection_types.UUID) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	delete(this.conns, uuid)
+	delete(this.connsExpensive, uuid)
+	delete(this.connsConsensus, uuid)
+}
+
+func (this *RateLimiterType) GetMetrics() *RateLimiterMetrics {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	throttledMethods := make(map[string]uint64, len(this.throttledMethods))
+	for method, count := range this.throttledMethods {
+		throttledMethods[method] = count
+	}
+
+	return &RateLimiterMetrics{
+		this.allowed,
+		this.throttled,
+		throttledMethods,
+		len(this.ips),
+		len(this.conns),
+	}
+}
+
+func (this *RateLimiterType) sweep() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	now := time.Now()
+	this.ips.sweep(now, network_config.NETWORK_RATE_LIMIT_IP)
+	this.ipsExpensive.sweep(now, network_config.NETWORK_RATE_LIMIT_EXPENSIVE)
+	this.conns.sweep(now, network_config.NETWORK_RATE_LIMIT_CONNECTION)
+	this.connsExpensive.sweep(now, network_config.NETWORK_RATE_LIMIT_EXPENSIVE)
+	this.connsConsensus.sweep(now, network_config.NETWORK_RATE_LIMIT_CONSENSUS)
+}
+
+func (this *RateLimiterType) Initialize() {
+	recovery.SafeGo(func() {
+		for {
+			time.Sleep(network_config.NETWORK_RATE_LIMIT_SWEEP_INTERVAL)
+			this.sweep()
+		}
+	})
+}
+
+var RateLimiter *RateLimiterType
+
+func init() {
+	RateLimiter = &RateLimiterType{
+		buckets[string]{},
+		buckets[string]{},
+		buckets[advanced_connection_types.UUID]{},
+		buckets[advanced_connection_types.UUID]{},
+		buckets[advanced_connection_types.UUID]{},
+		0,
+		0,
+		make(map[string]uint64),
+		&sync.Mutex{},
+	}
+}
@@ -0,0 +1,9 @@
+package rate_limiter
+
+type RateLimiterMetrics struct {
+	Allowed          uint64            `json:"allowed" msgpack:"allowed"`
+	Throttled        uint64            `json:"throttled" msgpack:"throttled"`
+	ThrottledMethods map[string]uint64 `json:"throttledMethods" msgpack:"throttledMethods"`
+	IPs              int               `json:"ips" msgpack:"ips"`                 //addresses with a bucket
+	Connections      int               `json:"connections" msgpack:"connections"` //connections with a bucket
+}
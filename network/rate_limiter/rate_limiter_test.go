@@ -0,0 +1,117 @@
+package rate_limiter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/network/network_config"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter() *RateLimiterType {
+	return &RateLimiterType{
+		buckets[string]{},
+		buckets[string]{},
+		buckets[advanced_connection_types.UUID]{},
+		buckets[advanced_connection_types.UUID]{},
+		buckets[advanced_connection_types.UUID]{},
+		0,
+		0,
+		make(map[string]uint64),
+		&sync.Mutex{},
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+
+	now := time.Now()
+	rate := float64(10)
+	burst := int(rate * network_config.NETWORK_RATE_LIMIT_BURST)
+
+	//the new bucket is filled by the first refill
+	bucket := &tokenBucket{last: now.Add(-time.Hour)}
+	for i := 0; i < burst; i++ {
+		assert.True(t, bucket.take(now, rate))
+	}
+	assert.False(t, bucket.take(now, rate))
+
+	//one token every 1/rate seconds
+	now = now.Add(time.Second / 10)
+	assert.True(t, bucket.take(now, rate))
+	assert.False(t, bucket.take(now, rate))
+
+	//it is never refilled over the burst
+	now = now.Add(time.Hour)
+	bucket.refill(now, rate)
+	assert.Equal(t, float64(burst), bucket.tokens)
+}
+
+func TestBuckets(t *testing.T) {
+
+	now := time.Now()
+	list := buckets[string]{}
+
+	//a rate of zero disables the limit
+	for i := 0; i < 1000; i++ {
+		assert.True(t, list.take("a", now, 0))
+	}
+	assert.Equal(t, 0, len(list))
+
+	burst := int(2 * network_config.NETWORK_RATE_LIMIT_BURST)
+	for i := 0; i < burst; i++ {
+		assert.True(t, list.take("a", now, 2))
+	}
+	assert.False(t, list.take("a", now, 2))
+	assert.True(t, list.take("b", now, 2))
+
+	//the buckets that are full again are removed
+	list.sweep(now, 2)
+	assert.Equal(t, 2, len(list))
+
+	list.sweep(now.Add(time.Duration(network_config.NETWORK_RATE_LIMIT_BURST)*time.Second), 2)
+	assert.Equal(t, 0, len(list))
+}
+
+func TestRateLimiter_AllowConnection(t *testing.T) {
+
+	limiter := newTestRateLimiter()
+	uuid := advanced_connection_types.UUID(100)
+
+	connectionBurst := int(float64(network_config.NETWORK_RATE_LIMIT_CONNECTION) * network_config.NETWORK_RATE_LIMIT_BURST)
+	for i := 0; i < connectionBurst; i++ {
+		assert.Nil(t, limiter.AllowConnection(uuid, "1.2.3.4", "block", false))
+	}
+	assert.NotNil(t, limiter.AllowConnection(uuid, "1.2.3.4", "block", false))
+	assert.NotNil(t, limiter.AllowConnection(uuid, "1.2.3.4", "network/nodes", true))
+
+	//the consensus methods have their own budget after the handshake
+	for _, method := range []string{"block", "block-hash", "block-miss-txs", "tx-raw"} {
+		assert.Nil(t, limiter.AllowConnection(uuid, "1.2.3.4", method, true))
+	}
+
+	//the other connections of the ip still have the rest of the ip budget
+	assert.Nil(t, limiter.AllowConnection(uuid+1, "1.2.3.4", "network/nodes", false))
+
+	metrics := limiter.GetMetrics()
+	assert.Equal(t, uint64(connectionBurst+5), metrics.Allowed)
+	assert.Equal(t, uint64(2), metrics.Throttled)
+	assert.Equal(t, map[string]uint64{"block": 1, "network/nodes": 1}, metrics.ThrottledMethods)
+
+	limiter.RemoveConnection(uuid)
+	assert.Nil(t, limiter.conns[uuid])
+	assert.Nil(t, limiter.connsConsensus[uuid])
+}
+
+func TestRateLimiter_Expensive(t *testing.T) {
+
+	limiter := newTestRateLimiter()
+
+	expensiveBurst := int(float64(network_config.NETWORK_RATE_LIMIT_EXPENSIVE) * network_config.NETWORK_RATE_LIMIT_BURST)
+	for i := 0; i < expensiveBurst; i++ {
+		assert.Nil(t, limiter.AllowIP("1.2.3.4", "tx/simulate"))
+	}
+	assert.NotNil(t, limiter.AllowIP("1.2.3.4", "tx/simulate"))
+	assert.Nil(t, limiter.AllowIP("1.2.3.4", "network/nodes"))
+	assert.Nil(t, limiter.AllowIP("1.2.3.5", "tx/simulate"))
+}
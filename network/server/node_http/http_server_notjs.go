@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/rs/cors"
-	"io"
 	"net/http"
 	"net/url"
 	"pandora-pay/blockchain"
@@ -15,11 +14,14 @@ import (
 	"pandora-pay/network/api_implementation/api_common"
 	"pandora-pay/network/api_implementation/api_http"
 	"pandora-pay/network/api_implementation/api_websockets"
+	"pandora-pay/network/banned_nodes"
 	"pandora-pay/network/network_config"
+	"pandora-pay/network/rate_limiter"
 	"pandora-pay/network/server/node_http_rpc"
 	"pandora-pay/network/websocks"
 	"pandora-pay/settings"
 	"pandora-pay/wallet"
+	"strings"
 )
 
 type httpServerType struct {
@@ -27,11 +29,18 @@ type httpServerType struct {
 	ApiWebsockets *api_websockets.APIWebsockets
 	ApiStore      *api_common.APIStore
 	GetMap        map[string]func(values url.Values) (any, error)
-	PostMap       map[string]func(values io.ReadCloser) (any, error)
+	PostMap       map[string]func(req *http.Request) (any, error)
 }
 
 var HttpServer *httpServerType
 
+func getRateLimitMethod(req *http.Request, known bool) string {
+	if !known {
+		return rate_limiter.UNKNOWN_METHOD
+	}
+	return strings.TrimPrefix(req.URL.Path, "/")
+}
+
 func (this *httpServerType) get(w http.ResponseWriter, req *http.Request) {
 
 	defer func() {
@@ -44,6 +53,11 @@ func (this *httpServerType) get(w http.ResponseWriter, req *http.Request) {
 	var output interface{}
 
 	callback := this.GetMap[req.URL.Path]
+	if err = rate_limiter.RateLimiter.AllowIP(banned_nodes.GetAddressKey(req.RemoteAddr), getRateLimitMethod(req, callback != nil)); err != nil {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+
 	if callback != nil {
 
 		var args url.Values
@@ -90,8 +104,13 @@ func (this *httpServerType) post(w http.ResponseWriter, req *http.Request) {
 	var output interface{}
 
 	callback := this.PostMap[req.URL.Path]
+	if err = rate_limiter.RateLimiter.AllowIP(banned_nodes.GetAddressKey(req.RemoteAddr), getRateLimitMethod(req, callback != nil)); err != nil {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+
 	if callback != nil {
-		output, err = callback(req.Body)
+		output, err = callback(req)
 	} else {
 		err = errors.New("Unknown request")
 	}
@@ -161,7 +180,7 @@ func NewHttpServer(chain *blockchain.Blockchain, settings *settings.Settings, me
 		apiWebsockets,
 		apiStore,
 		make(map[string]func(values url.Values) (any, error)),
-		make(map[string]func(values io.ReadCloser) (any, error)),
+		make(map[string]func(req *http.Request) (any, error)),
 	}
 
 	if err = node_http_rpc.InitializeRPC(apiCommon); err != nil {
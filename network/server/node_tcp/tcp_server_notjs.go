@@ -126,6 +126,11 @@ func NewTcpServer(settings *settings.Settings, chain *blockchain.Blockchain, mem
 
 	}
 
+	//the handshake of the permissioned networks is bound to the TLS session
+	if tlsConfig == nil && network_config.NETWORK_PERMISSIONED {
+		return errors.New("--network-permissioned requires a TLS certificate")
+	}
+
 	if shareAddress {
 
 		var u *url.URL
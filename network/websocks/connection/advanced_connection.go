@@ -9,8 +9,11 @@ import (
 	"pandora-pay/helpers/generics"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/helpers/recovery"
+	"pandora-pay/network/banned_nodes"
 	"pandora-pay/network/known_nodes/known_node"
 	"pandora-pay/network/network_config"
+	"pandora-pay/network/network_config/network_config_auth"
+	"pandora-pay/network/rate_limiter"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/network/websocks/websock"
 	"sync"
@@ -30,6 +33,10 @@ var uuidGenerator uint32 //use atomic
 
 type AdvancedConnection struct {
 	Authenticated            *abool.AtomicBool
+	AuthenticatedExpiration  *generics.Value[time.Time] //zero when the session never expires
+	AuthenticatedUser        *generics.Value[string]
+	AuthenticatedRole        *generics.Value[network_config_auth.ConfigAuthRole]
+	LoginChallenge           *generics.Value[[]byte]
 	UUID                     advanced_connection_types.UUID
 	Conn                     *websock.Conn
 	Handshake                *ConnectionHandshake
@@ -55,9 +62,42 @@ func (c *AdvancedConnection) GetTimeout() time.Duration {
 	return network_config.WEBSOCKETS_TIMEOUT
 }
 
+//the expired sessions are logged out
+func (c *AdvancedConnection) IsAuthenticated() bool {
+	if c.Authenticated.IsNotSet() {
+		return false
+	}
+	if expiration := c.AuthenticatedExpiration.Load(); !expiration.IsZero() && time.Now().After(expiration) {
+		c.Logout()
+		return false
+	}
+	return true
+}
+
+func (c *AdvancedConnection) Login(username string, role network_config_auth.ConfigAuthRole, expiration time.Time) {
+	c.AuthenticatedUser.Store(username)
+	c.AuthenticatedRole.Store(role)
+	c.AuthenticatedExpiration.Store(expiration)
+	c.Authenticated.Set()
+}
+
+func (c *AdvancedConnection) Logout() {
+	c.Authenticated.UnSet()
+	c.AuthenticatedExpiration.Store(time.Time{})
+	c.AuthenticatedUser.Store("")
+	c.AuthenticatedRole.Store(network_config_auth.ConfigAuthRole(""))
+}
+
+func (c *AdvancedConnection) IsInitialized() bool {
+	c.InitializedStatusMutex.Lock()
+	defer c.InitializedStatusMutex.Unlock()
+	return c.InitializedStatus == INITIALIZED_STATUS_INITIALIZED
+}
+
 func (c *AdvancedConnection) Close() error {
 	if c.IsClosed.SetToIf(false, true) {
 		close(c.Closed)
+		rate_limiter.RateLimiter.RemoveConnection(c.UUID)
 		c.onClosedConnection(c)
 		return c.Conn.Close()
 	}
@@ -189,13 +229,25 @@ func (c *AdvancedConnection) get(message *advanced_connection_types.AdvancedConn
 	var output any
 
 	route := string(message.Name)
-	if callback := c.getMap[route]; callback != nil {
-		output, err = callback(c, message.Data)
-	} else {
-		err = errors.New("Unknown request")
+	initialized := c.IsInitialized()
+	if network_config.NETWORK_PERMISSIONED && route != "handshake" && !initialized {
+		err = errors.New("Handshake is required")
+		return
 	}
 
-	if err != nil {
+	callback := c.getMap[route]
+	if callback == nil {
+		route = rate_limiter.UNKNOWN_METHOD
+	}
+	if err = rate_limiter.RateLimiter.AllowConnection(c.UUID, c.GetBanKey(), route, initialized); err != nil {
+		return
+	}
+
+	if callback == nil {
+		err = errors.New("Unknown request")
+		return
+	}
+	if output, err = callback(c, message.Data); err != nil {
 		return
 	}
 
@@ -269,9 +321,11 @@ func (c *AdvancedConnection) ReadPump() {
 
 		recovery.SafeGo(func() {
 			message := &advanced_connection_types.AdvancedConnectionMessage{}
-			if err = msgpack.Unmarshal(read, message); err == nil && message != nil {
-				c.processRead(message)
+			if err := msgpack.Unmarshal(read, message); err != nil {
+				c.Penalize(network_config.NETWORK_PENALTY_MALFORMED_MESSAGE, "Malformed message")
+				return
 			}
+			c.processRead(message)
 		})
 
 	}
@@ -309,6 +363,20 @@ func (c *AdvancedConnection) SendPings() {
 
 }
 
+//the incoming connections are identified by the ip because the url from the handshake can't be trusted
+func (c *AdvancedConnection) GetBanKey() string {
+	if c.ConnectionType {
+		return banned_nodes.GetAddressKey(c.RemoteAddr)
+	}
+	return c.RemoteAddr
+}
+
+func (c *AdvancedConnection) Penalize(points int32, message string) {
+	if banned_nodes.BannedNodes.Penalize(c.GetBanKey(), points, message) {
+		c.Close()
+	}
+}
+
 func (c *AdvancedConnection) IncreaseKnownNodeScore() {
 
 	ticker := time.NewTicker(network_config.WEBSOCKETS_INCREASE_KNOWN_NODE_SCORE_INTERVAL)
@@ -339,6 +407,10 @@ func NewAdvancedConnection(conn *websock.Conn, remoteAddr string, knownNode *kno
 
 	advancedConnection := &AdvancedConnection{
 		abool.New(),
+		&generics.Value[time.Time]{},
+		&generics.Value[string]{},
+		&generics.Value[network_config_auth.ConfigAuthRole]{},
+		&generics.Value[[]byte]{},
 		uuid,
 		conn,
 		nil,
@@ -359,6 +431,7 @@ func NewAdvancedConnection(conn *websock.Conn, remoteAddr string, knownNode *kno
 		onClosedConnection,
 		onIncreaseKnownNodeScore,
 	}
+	advancedConnection.LoginChallenge.Store([]byte{})
 	advancedConnection.Subscriptions = NewSubscriptions(advancedConnection, newSubscriptionCn, removeSubscriptionCn)
 	return advancedConnection, nil
 }
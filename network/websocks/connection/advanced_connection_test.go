@@ -0,0 +1,43 @@
+package connection
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/helpers"
+	"pandora-pay/network/rate_limiter"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"sync"
+	"testing"
+)
+
+func TestAdvancedConnection_GetUnknownRoutes(t *testing.T) {
+
+	c := &AdvancedConnection{
+		UUID:                   advanced_connection_types.UUID(1000),
+		RemoteAddr:             "1.2.3.4",
+		InitializedStatusMutex: &sync.Mutex{},
+		getMap: map[string]func(conn *AdvancedConnection, values []byte) (any, error){
+			"ping": func(conn *AdvancedConnection, values []byte) (any, error) {
+				return "pong", nil
+			},
+		},
+	}
+	defer rate_limiter.RateLimiter.RemoveConnection(c.UUID)
+
+	out, err := c.get(&advanced_connection_types.AdvancedConnectionMessage{Name: []byte("ping")})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("pong"), out)
+
+	//the unknown routes are throttled under a single method
+	throttled := rate_limiter.RateLimiter.GetMetrics().Throttled
+	for i := 0; i < 1000; i++ {
+		_, err = c.get(&advanced_connection_types.AdvancedConnectionMessage{Name: []byte(hex.EncodeToString(helpers.RandomBytes(8)))})
+		assert.NotNil(t, err)
+	}
+
+	metrics := rate_limiter.RateLimiter.GetMetrics()
+	assert.Greater(t, metrics.Throttled, throttled)
+	for method := range metrics.ThrottledMethods {
+		assert.Equal(t, rate_limiter.UNKNOWN_METHOD, method)
+	}
+}
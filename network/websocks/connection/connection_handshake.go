@@ -12,6 +12,9 @@ type ConnectionHandshake struct {
 	Network   uint64                   `json:"network" msgpack:"network"`
 	Consensus config.NodeConsensusType `json:"consensus" msgpack:"consensus"`
 	URL       string                   `json:"url" msgpack:"url"`
+	//the node key and the signature of the challenge received with the handshake request
+	NodePublicKey []byte `json:"nodePublicKey,omitempty" msgpack:"nodePublicKey,omitempty"`
+	NodeSignature []byte `json:"nodeSignature,omitempty" msgpack:"nodeSignature,omitempty"`
 }
 
 func (handshake *ConnectionHandshake) ValidateHandshake() (*semver.Version, error) {
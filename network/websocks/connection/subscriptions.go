@@ -29,6 +29,8 @@ func checkSubscriptionLength(key []byte, subscriptionType api_code_types.Subscri
 		length = config_coins.ASSET_LENGTH
 	case api_code_types.SUBSCRIPTION_TRANSACTION:
 		length = cryptography.HashSize
+	case api_code_types.SUBSCRIPTION_BLOCKS, api_code_types.SUBSCRIPTION_MEMPOOL:
+		length = 0
 	}
 	if len(key) != length {
 		return errors.New("Key is invalid")
@@ -202,6 +202,11 @@ func (c *Conn) Close() error {
 	return nil
 }
 
+//the browser doesn't expose the TLS session
+func (c *Conn) GetChannelBinding() ([]byte, error) {
+	return nil, errors.New("Channel binding is not available in the browser")
+}
+
 func (c *Conn) closedEvent(e *CloseError, wasClean bool) {
 	c.closedErrorOnce.Do(func() {
 		close(c.closed)
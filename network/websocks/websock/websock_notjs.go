@@ -4,6 +4,8 @@
 package websock
 
 import (
+	"crypto/tls"
+	"errors"
 	"github.com/gorilla/websocket"
 	"golang.org/x/net/proxy"
 	"net"
@@ -60,3 +62,13 @@ func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
 
 	return &Conn{c}, nil
 }
+
+//the keying material exported from the TLS session. Both ends of a wss connection get the same value, while a relay has a different TLS session on each side
+func (c *Conn) GetChannelBinding() ([]byte, error) {
+	tlsConn, ok := c.UnderlyingConn().(*tls.Conn)
+	if !ok {
+		return nil, errors.New("Connection is not using TLS")
+	}
+	state := tlsConn.ConnectionState()
+	return state.ExportKeyingMaterial("EXPORTER-pandora-pay-node-handshake", nil, 32)
+}
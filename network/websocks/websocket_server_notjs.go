@@ -6,6 +6,7 @@ package websocks
 import (
 	"net/http"
 	"pandora-pay/helpers/recovery"
+	"pandora-pay/network/banned_nodes"
 	"pandora-pay/network/connected_nodes"
 	"pandora-pay/network/known_nodes"
 	"pandora-pay/network/network_config"
@@ -20,6 +21,11 @@ func (this *websocketsType) HandleUpgradeConnection(w http.ResponseWriter, r *ht
 		return
 	}
 
+	if banned_nodes.BannedNodes.IsAddressBanned(r.RemoteAddr) {
+		http.Error(w, "Banned", 403)
+		return
+	}
+
 	c, err := websock.Upgrade(w, r)
 	if err != nil {
 		return
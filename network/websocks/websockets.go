@@ -8,6 +8,7 @@ import (
 	"pandora-pay/blockchain"
 	"pandora-pay/config"
 	"pandora-pay/config/globals"
+	"pandora-pay/cryptography"
 	"pandora-pay/gui"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/helpers/msgpack"
@@ -19,6 +20,7 @@ import (
 	"pandora-pay/network/known_nodes"
 	"pandora-pay/network/known_nodes/known_node"
 	"pandora-pay/network/network_config"
+	"pandora-pay/network/node_identity"
 	"pandora-pay/network/websocks/connection"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/network/websocks/websock"
@@ -208,7 +210,9 @@ func (this *websocketsType) InitializeConnection(conn *connection.AdvancedConnec
 		}
 	}()
 
-	out := conn.SendAwaitAnswer([]byte("handshake"), nil, nil, 0)
+	challenge := cryptography.RandomHash()
+
+	out := conn.SendAwaitAnswer([]byte("handshake"), node_identity.GetHandshakeRequest(challenge, node_identity.NodeIdentity.PublicKey), nil, 0)
 
 	if out.Err != nil {
 		return errors.New("Error sending handshake")
@@ -231,6 +235,18 @@ func (this *websocketsType) InitializeConnection(conn *connection.AdvancedConnec
 		return errors.New("Socket is banned")
 	}
 
+	//the permissioned networks use only wss connections as the handshake is bound to the TLS session
+	var channelBinding []byte
+	if network_config.NETWORK_PERMISSIONED {
+		if channelBinding, err = conn.Conn.GetChannelBinding(); err != nil {
+			return
+		}
+	}
+
+	if err = node_identity.NodeIdentity.VerifyHandshake(challenge, channelBinding, handshakeReceived.NodePublicKey, handshakeReceived.NodeSignature, conn.ConnectionType); err != nil {
+		return
+	}
+
 	conn.Handshake = handshakeReceived
 	conn.Version = version
 
@@ -2,6 +2,7 @@ package websocks
 
 import (
 	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/blockchain_types"
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/msgpack"
 	"pandora-pay/helpers/recovery"
@@ -23,6 +24,8 @@ type WebsocketSubscriptions struct {
 	accountsTransactionsSubscriptions map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	assetsSubscriptions               map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 	transactionsSubscriptions         map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
+	blocksSubscriptions               map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
+	mempoolSubscriptions              map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 }
 
 func newWebsocketSubscriptions(chain *blockchain.Blockchain, mempool *mempool.Mempool) (subs *WebsocketSubscriptions) {
@@ -35,6 +38,8 @@ func newWebsocketSubscriptions(chain *blockchain.Blockchain, mempool *mempool.Me
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
+		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
+		make(map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification),
 	}
 
 	if network_config.NETWORK_ENABLE_SUBSCRIPTIONS {
@@ -93,6 +98,33 @@ func (this *WebsocketSubscriptions) send(subscriptionType api_code_types.Subscri
 	}
 }
 
+func getMempoolNotificationExtra(txUpdate *blockchain_types.MempoolTransactionUpdate) *api_types.APISubscriptionNotificationMempoolExtra {
+	return &api_types.APISubscriptionNotificationMempoolExtra{
+		txUpdate.Inserted, txUpdate.IncludedInBlockchainNotification, txUpdate.FeePerByte, txUpdate.Tx.Bloom.Size,
+	}
+}
+
+//the removed txs are sent only with the first block of the update
+func getBlocksNotificationsExtra(chainUpdate *blockchain_types.BlockchainUpdates) []*api_types.APISubscriptionNotificationBlockExtra {
+
+	extras := make([]*api_types.APISubscriptionNotificationBlockExtra, len(chainUpdate.InsertedBlocks))
+	for i, blkComplete := range chainUpdate.InsertedBlocks {
+
+		extras[i] = &api_types.APISubscriptionNotificationBlockExtra{
+			Height: blkComplete.Height,
+			Txs:    make([][]byte, len(blkComplete.Txs)),
+		}
+		for j, tx := range blkComplete.Txs {
+			extras[i].Txs[j] = tx.Bloom.Hash
+		}
+		if i == 0 {
+			extras[i].RemovedTxs = chainUpdate.RemovedTxs
+		}
+	}
+
+	return extras
+}
+
 func (this *WebsocketSubscriptions) getSubsMap(subscriptionType api_code_types.SubscriptionType) (subsMap map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification) {
 	switch subscriptionType {
 	case api_code_types.SUBSCRIPTION_ACCOUNT, api_code_types.SUBSCRIPTION_PLAIN_ACCOUNT, api_code_types.SUBSCRIPTION_REGISTRATION:
@@ -103,6 +135,10 @@ func (this *WebsocketSubscriptions) getSubsMap(subscriptionType api_code_types.S
 		subsMap = this.assetsSubscriptions
 	case api_code_types.SUBSCRIPTION_TRANSACTION:
 		subsMap = this.transactionsSubscriptions
+	case api_code_types.SUBSCRIPTION_BLOCKS:
+		subsMap = this.blocksSubscriptions
+	case api_code_types.SUBSCRIPTION_MEMPOOL:
+		subsMap = this.mempoolSubscriptions
 	}
 	return
 }
@@ -136,6 +172,9 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 	updateMempoolTransactionsCn := this.mempool.Txs.UpdateMempoolTransactions.AddListener()
 	defer this.mempool.Txs.UpdateMempoolTransactions.RemoveChannel(updateMempoolTransactionsCn)
 
+	updateNewChainUpdateCn := this.chain.UpdateNewChainUpdate.AddListener()
+	defer this.chain.UpdateNewChainUpdate.RemoveChannel(updateNewChainUpdateCn)
+
 	var subsMap map[string]map[advanced_connection_types.UUID]*connection.SubscriptionNotification
 
 	for {
@@ -274,6 +313,22 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 				})
 			}
 
+			if list := this.mempoolSubscriptions[""]; list != nil {
+				this.send(api_code_types.SUBSCRIPTION_MEMPOOL, []byte("sub/notify"), txUpdate.Tx.Bloom.Hash, list, nil, nil, getMempoolNotificationExtra(txUpdate))
+			}
+
+		case chainUpdate, ok := <-updateNewChainUpdateCn:
+			if !ok {
+				return
+			}
+
+			if list := this.blocksSubscriptions[""]; list != nil {
+				extras := getBlocksNotificationsExtra(chainUpdate)
+				for i, blkComplete := range chainUpdate.InsertedBlocks {
+					this.send(api_code_types.SUBSCRIPTION_BLOCKS, []byte("sub/notify"), blkComplete.Bloom.Hash, list, blkComplete.Block, nil, extras[i])
+				}
+			}
+
 		case conn, ok := <-this.websocketClosedCn:
 			if !ok {
 				return
@@ -283,6 +338,8 @@ func (this *WebsocketSubscriptions) processSubscriptions() {
 			this.removeConnection(conn, api_code_types.SUBSCRIPTION_ACCOUNT_TRANSACTIONS)
 			this.removeConnection(conn, api_code_types.SUBSCRIPTION_ASSET)
 			this.removeConnection(conn, api_code_types.SUBSCRIPTION_TRANSACTION)
+			this.removeConnection(conn, api_code_types.SUBSCRIPTION_BLOCKS)
+			this.removeConnection(conn, api_code_types.SUBSCRIPTION_MEMPOOL)
 
 		}
 
@@ -0,0 +1,55 @@
+package websocks
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/blockchain/blockchain_types"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/cryptography"
+	"testing"
+)
+
+func newTestNotificationTx() *transaction.Transaction {
+	hash := cryptography.RandomHash()
+	return &transaction.Transaction{Bloom: &transaction.TransactionBloom{Size: 200, Hash: hash, HashStr: string(hash)}}
+}
+
+func TestGetBlocksNotificationsExtra(t *testing.T) {
+
+	blocks := make([]*block_complete.BlockComplete, 3)
+	for i := range blocks {
+		blocks[i] = block_complete.CreateEmptyBlockComplete()
+		blocks[i].Height = uint64(10 + i)
+		blocks[i].Txs = []*transaction.Transaction{newTestNotificationTx(), newTestNotificationTx()}
+	}
+
+	removedTxs := [][]byte{cryptography.RandomHash(), cryptography.RandomHash()}
+	extras := getBlocksNotificationsExtra(&blockchain_types.BlockchainUpdates{InsertedBlocks: blocks, RemovedTxs: removedTxs})
+
+	assert.Equal(t, 3, len(extras))
+	for i, extra := range extras {
+		assert.Equal(t, uint64(10+i), extra.Height)
+		assert.Equal(t, [][]byte{blocks[i].Txs[0].Bloom.Hash, blocks[i].Txs[1].Bloom.Hash}, extra.Txs)
+	}
+
+	//the removed txs are reported only once
+	assert.Equal(t, removedTxs, extras[0].RemovedTxs)
+	assert.Nil(t, extras[1].RemovedTxs)
+	assert.Nil(t, extras[2].RemovedTxs)
+}
+
+func TestGetMempoolNotificationExtra(t *testing.T) {
+
+	tx := newTestNotificationTx()
+
+	extra := getMempoolNotificationExtra(&blockchain_types.MempoolTransactionUpdate{Inserted: true, Tx: tx, FeePerByte: 25})
+	assert.True(t, extra.Inserted)
+	assert.False(t, extra.Included)
+	assert.Equal(t, uint64(25), extra.FeePerByte)
+	assert.Equal(t, uint64(200), extra.Size)
+
+	extra = getMempoolNotificationExtra(&blockchain_types.MempoolTransactionUpdate{Inserted: false, Tx: tx, IncludedInBlockchainNotification: true, FeePerByte: 25})
+	assert.False(t, extra.Inserted)
+	assert.True(t, extra.Included)
+	assert.Equal(t, uint64(25), extra.FeePerByte)
+}
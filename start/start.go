@@ -81,6 +81,17 @@ func StartMainNow() (err error) {
 	}
 	globals.MainEvents.BroadcastEvent("main", "blockchain initialized")
 
+	if arguments.Arguments["--snapshot-import"] != nil {
+		expectedHash := ""
+		if arguments.Arguments["--snapshot-hash"] != nil {
+			expectedHash = arguments.Arguments["--snapshot-hash"].(string)
+		}
+		if err = app.Chain.ImportSnapshot(arguments.Arguments["--snapshot-import"].(string), expectedHash); err != nil {
+			return
+		}
+		globals.MainEvents.BroadcastEvent("main", "snapshot imported")
+	}
+
 	if app.Wallet, err = wallet.CreateWallet(app.Forging, app.Mempool, app.AddressBalanceDecryptor); err != nil {
 		return
 	}
@@ -97,6 +108,18 @@ func StartMainNow() (err error) {
 		return
 	}
 
+	if arguments.Arguments["--snapshot-export"] != nil {
+		height := app.Chain.GetChainData().Height - 1
+		if arguments.Arguments["--snapshot-export-height"] != nil {
+			if height, err = strconv.ParseUint(arguments.Arguments["--snapshot-export-height"].(string), 10, 64); err != nil {
+				return
+			}
+		}
+		if err = app.Chain.ExportSnapshot(arguments.Arguments["--snapshot-export"].(string), height); err != nil {
+			return
+		}
+	}
+
 	if runtime.GOARCH != "wasm" && arguments.Arguments["--balance-decryptor-disable-init"] == false {
 		tableSize := 0
 		if arguments.Arguments["--balance-decryptor-table-size"] != nil {
@@ -155,6 +178,14 @@ func StartMainNow() (err error) {
 
 	chain_network.InitChainNetwork(app.Chain, app.Mempool)
 
+	if config.NODE_CONSENSUS == config.NODE_CONSENSUS_TYPE_FULL && runtime.GOARCH != "wasm" {
+		go func() {
+			if err := app.Mempool.LoadStoredTxs(app.Chain.GetChainData().Height); err != nil {
+				gui.GUI.Error("Error restoring mempool txs", err)
+			}
+		}()
+	}
+
 	gui.GUI.Log("Main Loop")
 	globals.MainEvents.BroadcastEvent("main", "initialized")
 
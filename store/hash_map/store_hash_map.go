@@ -8,6 +8,7 @@ import (
 	"pandora-pay/helpers/advanced_buffers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
 	"strconv"
 )
 
@@ -103,6 +104,38 @@ func (hashMap *HashMap[T]) GetRandom() (T, error) {
 	return hashMap.GetByIndex(index)
 }
 
+// support only for commited data. The keys are visited in lexicographic order
+func (hashMap *HashMap[T]) Iterate(start string, reverse bool, callback func(key string, element T) (bool, error)) error {
+
+	if hashMap.changed {
+		return errors.New("Iterate is supported only when is committed")
+	}
+
+	prefix := hashMap.name + ":map:"
+	return hashMap.Tx.Iterate(prefix, start, reverse, func(key string, data []byte) (bool, error) {
+
+		key = key[len(prefix):]
+
+		var index uint64
+		if hashMap.Indexable {
+			indexData := hashMap.Tx.Get(hashMap.name + ":listKeys:" + key)
+			if indexData == nil {
+				return false, errors.New("Key not found")
+			}
+			var err error
+			if index, err = strconv.ParseUint(string(indexData), 10, 64); err != nil {
+				return false, err
+			}
+		}
+
+		element, err := hashMap.deserialize([]byte(key), data, index)
+		if err != nil {
+			return false, err
+		}
+		return callback(key, element)
+	})
+}
+
 func (hashMap *HashMap[T]) Get(key string) (out T, err error) {
 
 	if hashMap.keyLength != 0 && len(key) != hashMap.keyLength {
@@ -278,6 +311,17 @@ func (hashMap *HashMap[T]) UpdateOrDelete(key string, data T) error {
 	return hashMap.Update(key, data)
 }
 
+//the keys that were updated or deleted and not committed yet
+func (hashMap *HashMap[T]) GetChangedKeys() (out []string) {
+	for k, v := range hashMap.Changes {
+		if v.Status == "update" || v.Status == "del" {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return
+}
+
 func (hashMap *HashMap[T]) ComputeChangesSize() (out uint64) {
 
 	for k, v := range hashMap.changesSize {
@@ -0,0 +1,43 @@
+package hash_map
+
+import (
+	"pandora-pay/helpers"
+	"pandora-pay/store/state_tree"
+	"pandora-pay/store/store_db/store_db_interface"
+)
+
+//it must be called before CommitChanges as the changes are cleared afterwards. The tree applies them on Commit
+func (hashMap *HashMap[T]) WriteStateTree(tree *state_tree.StateTree) {
+	for k, v := range hashMap.Changes {
+		if v.Status == "update" {
+			tree.Set(hashMap.name, []byte(k), helpers.SerializeToBytes(v.Element))
+		} else if v.Status == "del" {
+			tree.Set(hashMap.name, []byte(k), nil)
+		}
+	}
+}
+
+func (hashMap *HashMap[T]) GetName() string {
+	return hashMap.name
+}
+
+//the committed elements of the hashmap are written in the tree, at most limit of them. It returns true when there are no more elements after start
+func WriteStoredStateTree(tx store_db_interface.StoreDBTransactionInterface, tree *state_tree.StateTree, name string, start *string, limit int) (bool, error) {
+
+	prefix := name + ":map:"
+
+	count := 0
+	if err := tx.Iterate(prefix, *start, false, func(key string, value []byte) (bool, error) {
+		if count == limit {
+			return false, nil
+		}
+		tree.Set(name, []byte(key[len(prefix):]), helpers.CloneBytes(value))
+		*start = key[len(prefix):] + "\x00"
+		count++
+		return true, nil
+	}); err != nil {
+		return false, err
+	}
+
+	return count < limit, tree.Commit()
+}
@@ -2,6 +2,7 @@ package hash_map
 
 import (
 	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/store/state_tree"
 	"pandora-pay/store/store_db/store_db_interface"
 )
 
@@ -14,6 +15,8 @@ type HashMapInterface interface {
 	WriteTransitionalChangesToStore(prefix string) (bool, error)
 	DeleteTransitionalChangesFromStore(prefix string)
 	ReadTransitionalChangesFromStore(prefix string) error
+	WriteStateTree(tree *state_tree.StateTree)
+	GetName() string
 }
 
 type HashMapElementSerializableInterface interface {
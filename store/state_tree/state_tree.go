@@ -0,0 +1,210 @@
+package state_tree
+
+import (
+	"bytes"
+	"errors"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"strconv"
+)
+
+/**
+Sparse Merkle Tree over all the HashMaps
+Every (hashmap name, key) is mapped to a leaf at the path SHA3(name:key)
+The root doesn't depend on the order of the updates, so reverting the changes restores the previous root
+*/
+
+const depth = cryptography.HashSize * 8
+
+var defaultHashes [depth + 1][]byte
+
+type StateTree struct {
+	Tx      store_db_interface.StoreDBTransactionInterface
+	pending map[string][]byte //leaves by path waiting to be committed
+}
+
+type StateProof struct {
+	Bitmap   []byte   `json:"bitmap" msgpack:"bitmap"` //bit i is set when the sibling at level i is not the default one
+	Siblings [][]byte `json:"siblings" msgpack:"siblings"`
+}
+
+func hashNode(left, right []byte) []byte {
+	data := make([]byte, len(left)+len(right))
+	copy(data, left)
+	copy(data[len(left):], right)
+	return cryptography.SHA3(data)
+}
+
+func getBit(path []byte, index int) bool {
+	return path[index/8]&(1<<(7-uint(index%8))) != 0
+}
+
+func flipBit(path []byte, index int) {
+	path[index/8] ^= 1 << (7 - uint(index%8))
+}
+
+//path of the node at the level. Level 0 are the leaves and level depth is the root
+func maskPath(path []byte, level int) []byte {
+	out := helpers.CloneBytes(path)
+	for i := depth - level; i < depth; i++ {
+		out[i/8] &^= 1 << (7 - uint(i%8))
+	}
+	return out
+}
+
+func GetPath(name string, key []byte) []byte {
+	return cryptography.SHA3([]byte(name + ":" + string(key)))
+}
+
+func GetLeaf(path, value []byte) []byte {
+	if value == nil {
+		return defaultHashes[0]
+	}
+	return hashNode(path, cryptography.SHA3(value))
+}
+
+func (tree *StateTree) getNode(level int, path []byte) []byte {
+	if data := tree.Tx.Get("stateTree:" + strconv.Itoa(level) + ":" + string(path)); data != nil {
+		return helpers.CloneBytes(data)
+	}
+	return defaultHashes[level]
+}
+
+func (tree *StateTree) setNode(level int, path, node []byte) {
+	if bytes.Equal(node, defaultHashes[level]) {
+		tree.Tx.Delete("stateTree:" + strconv.Itoa(level) + ":" + string(path))
+	} else {
+		tree.Tx.Put("stateTree:"+strconv.Itoa(level)+":"+string(path), node)
+	}
+}
+
+func (tree *StateTree) GetRoot() []byte {
+	return tree.getNode(depth, make([]byte, cryptography.HashSize))
+}
+
+//value nil means the key was deleted. The change is applied by Commit
+func (tree *StateTree) Set(name string, key, value []byte) {
+	path := GetPath(name, key)
+	tree.pending[string(path)] = GetLeaf(path, value)
+}
+
+//the pending leaves are applied level by level, so every inner node is computed and written only once
+func (tree *StateTree) Commit() error {
+
+	if len(tree.pending) == 0 {
+		return nil
+	}
+
+	if !tree.Tx.IsWritable() {
+		return errors.New("State tree requires a writable transaction")
+	}
+
+	nodes := tree.pending
+	tree.pending = make(map[string][]byte)
+
+	for level := 0; level < depth; level++ {
+
+		parents := make(map[string][]byte, len(nodes))
+
+		for nodePathStr, node := range nodes {
+
+			nodePath := []byte(nodePathStr)
+			tree.setNode(level, nodePath, node)
+
+			parentPath := string(maskPath(nodePath, level+1))
+			if parents[parentPath] != nil {
+				continue
+			}
+
+			siblingPath := helpers.CloneBytes(nodePath)
+			flipBit(siblingPath, depth-level-1)
+			sibling := nodes[string(siblingPath)]
+			if sibling == nil {
+				sibling = tree.getNode(level, siblingPath)
+			}
+
+			if getBit(nodePath, depth-level-1) {
+				parents[parentPath] = hashNode(sibling, node)
+			} else {
+				parents[parentPath] = hashNode(node, sibling)
+			}
+		}
+
+		nodes = parents
+	}
+
+	root := make([]byte, cryptography.HashSize)
+	tree.setNode(depth, root, nodes[string(root)])
+
+	return nil
+}
+
+//value nil means the key was deleted
+func (tree *StateTree) Update(name string, key, value []byte) error {
+	tree.Set(name, key, value)
+	return tree.Commit()
+}
+
+func (tree *StateTree) GetProof(name string, key []byte) *StateProof {
+
+	path := GetPath(name, key)
+	proof := &StateProof{
+		make([]byte, cryptography.HashSize),
+		[][]byte{},
+	}
+
+	for level := 0; level < depth; level++ {
+		siblingPath := maskPath(path, level)
+		flipBit(siblingPath, depth-level-1)
+		if sibling := tree.getNode(level, siblingPath); !bytes.Equal(sibling, defaultHashes[level]) {
+			proof.Bitmap[level/8] |= 1 << (7 - uint(level%8))
+			proof.Siblings = append(proof.Siblings, sibling)
+		}
+	}
+
+	return proof
+}
+
+//value nil verifies that the key doesn't exist
+func VerifyProof(root []byte, name string, key, value []byte, proof *StateProof) bool {
+
+	if proof == nil || len(proof.Bitmap) != cryptography.HashSize {
+		return false
+	}
+
+	path := GetPath(name, key)
+	node := GetLeaf(path, value)
+
+	c := 0
+	for level := 0; level < depth; level++ {
+
+		sibling := defaultHashes[level]
+		if getBit(proof.Bitmap, level) {
+			if c >= len(proof.Siblings) || len(proof.Siblings[c]) != cryptography.HashSize {
+				return false
+			}
+			sibling = proof.Siblings[c]
+			c += 1
+		}
+
+		if getBit(path, depth-level-1) {
+			node = hashNode(sibling, node)
+		} else {
+			node = hashNode(node, sibling)
+		}
+	}
+
+	return c == len(proof.Siblings) && bytes.Equal(node, root)
+}
+
+func NewStateTree(tx store_db_interface.StoreDBTransactionInterface) *StateTree {
+	return &StateTree{tx, make(map[string][]byte)}
+}
+
+func init() {
+	defaultHashes[0] = make([]byte, cryptography.HashSize)
+	for i := 1; i <= depth; i++ {
+		defaultHashes[i] = hashNode(defaultHashes[i-1], defaultHashes[i-1])
+	}
+}
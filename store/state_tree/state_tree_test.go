@@ -0,0 +1,88 @@
+package state_tree
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/cryptography"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func TestStateTree(t *testing.T) {
+
+	db, err := store_db_memory.CreateStoreDBMemory("test")
+	assert.Nil(t, err)
+
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+		tree := NewStateTree(writer)
+		emptyRoot := tree.GetRoot()
+
+		keys := make([][]byte, 10)
+		values := make([][]byte, 10)
+		for i := range keys {
+			keys[i] = cryptography.RandomHash()
+			values[i] = cryptography.RandomHash()
+			assert.Nil(t, tree.Update("test", keys[i], values[i]))
+		}
+
+		root := tree.GetRoot()
+		for i := range keys {
+			proof := tree.GetProof("test", keys[i])
+			assert.True(t, VerifyProof(root, "test", keys[i], values[i], proof), "State Proof is invalid")
+			assert.False(t, VerifyProof(root, "test", keys[i], cryptography.RandomHash(), proof), "State Proof should be invalid")
+			assert.False(t, VerifyProof(root, "test2", keys[i], values[i], proof), "State Proof should be invalid")
+		}
+
+		missing := cryptography.RandomHash()
+		assert.True(t, VerifyProof(root, "test", missing, nil, tree.GetProof("test", missing)), "State Proof of absence is invalid")
+
+		//the root doesn't depend on the order of the updates
+		for i := len(keys) - 1; i >= 0; i-- {
+			assert.Nil(t, tree.Update("test", keys[i], nil))
+		}
+		assert.Equal(t, emptyRoot, tree.GetRoot(), "State Tree root should be empty")
+
+		return
+	}))
+
+}
+
+func TestStateTree_Commit(t *testing.T) {
+
+	keys := make([][]byte, 50)
+	values := make([][]byte, 50)
+	for i := range keys {
+		keys[i] = cryptography.RandomHash()
+		values[i] = cryptography.RandomHash()
+	}
+
+	roots := make([][]byte, 2)
+	for batched := range roots {
+
+		db, err := store_db_memory.CreateStoreDBMemory("test")
+		assert.Nil(t, err)
+
+		assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			tree := NewStateTree(writer)
+			for i := range keys {
+				if batched == 1 {
+					tree.Set("test", keys[i], values[i])
+				} else {
+					assert.Nil(t, tree.Update("test", keys[i], values[i]))
+				}
+			}
+			assert.Nil(t, tree.Commit())
+
+			roots[batched] = tree.GetRoot()
+			for i := range keys {
+				assert.True(t, VerifyProof(roots[batched], "test", keys[i], values[i], tree.GetProof("test", keys[i])), "State Proof is invalid")
+			}
+
+			return
+		}))
+	}
+
+	assert.Equal(t, roots[0], roots[1], "Batched commit should give the same root")
+}
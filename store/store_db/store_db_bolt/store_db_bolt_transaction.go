@@ -36,3 +36,47 @@ func (tx *StoreDBBoltTransaction) Exists(key string) bool {
 func (tx *StoreDBBoltTransaction) Delete(key string) {
 	tx.bucket.Delete([]byte(key))
 }
+
+func (tx *StoreDBBoltTransaction) Iterate(prefix, start string, reverse bool, callback func(key string, value []byte) (bool, error)) (err error) {
+
+	c := tx.bucket.Cursor()
+
+	var k, v []byte
+	if !reverse {
+		k, v = c.Seek([]byte(prefix + start))
+	} else {
+
+		pivot := prefix + start
+		if start == "" {
+			pivot = store_db_interface.GetPrefixEnd(prefix)
+		}
+
+		if pivot == "" {
+			k, v = c.Last()
+		} else if k, v = c.Seek([]byte(pivot)); k == nil {
+			k, v = c.Last()
+		} else if start == "" || string(k) != pivot {
+			k, v = c.Prev()
+		}
+	}
+
+	for ; k != nil && store_db_interface.InRange(string(k), prefix, start, reverse); k, v = tx.next(c, reverse) {
+		var next bool
+		if next, err = callback(string(k), helpers.CloneBytes(v)); err != nil || !next {
+			return
+		}
+	}
+
+	return
+}
+
+func (tx *StoreDBBoltTransaction) next(c *bolt.Cursor, reverse bool) ([]byte, []byte) {
+	if reverse {
+		return c.Prev()
+	}
+	return c.Next()
+}
+
+func (tx *StoreDBBoltTransaction) DeleteRange(prefix, start, end string) error {
+	return store_db_interface.DeleteRange(tx, prefix, start, end)
+}
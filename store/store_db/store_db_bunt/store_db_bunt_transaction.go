@@ -41,7 +41,44 @@ func (tx *StoreDBBuntTransaction) Exists(key string) bool {
 
 func (tx *StoreDBBuntTransaction) Delete(key string) {
 	_, err := tx.buntTx.Delete(key)
-	if err != buntdb.ErrNotFound {
+	if err != nil && err != buntdb.ErrNotFound {
 		panic(err)
 	}
 }
+
+func (tx *StoreDBBuntTransaction) Iterate(prefix, start string, reverse bool, callback func(key string, value []byte) (bool, error)) (err error) {
+
+	iterator := func(key, value string) bool {
+		if !store_db_interface.InRange(key, prefix, start, reverse) {
+			return false
+		}
+		var next bool
+		next, err = callback(key, []byte(value))
+		return err == nil && next
+	}
+
+	var err2 error
+	if !reverse {
+		err2 = tx.buntTx.AscendGreaterOrEqual("", prefix+start, iterator)
+	} else if start != "" {
+		err2 = tx.buntTx.DescendLessOrEqual("", prefix+start, iterator)
+	} else if end := store_db_interface.GetPrefixEnd(prefix); end != "" {
+		err2 = tx.buntTx.DescendLessOrEqual("", end, func(key, value string) bool {
+			if key == end {
+				return true
+			}
+			return iterator(key, value)
+		})
+	} else {
+		err2 = tx.buntTx.Descend("", iterator)
+	}
+
+	if err2 != nil {
+		return err2
+	}
+	return
+}
+
+func (tx *StoreDBBuntTransaction) DeleteRange(prefix, start, end string) error {
+	return store_db_interface.DeleteRange(tx, prefix, start, end)
+}
@@ -5,3 +5,8 @@ type StoreDBInterface interface {
 	View(callback func(dbTx StoreDBTransactionInterface) error) error
 	Update(callback func(dbTx StoreDBTransactionInterface) error) error
 }
+
+//the stores that can reclaim the space of the deleted keys
+type StoreDBCompactInterface interface {
+	Compact() error
+}
@@ -0,0 +1,57 @@
+package store_db_interface
+
+import (
+	"errors"
+	"strings"
+)
+
+//it returns the smallest key that is greater than all the keys with the prefix. Empty when there is none
+func GetPrefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i] += 1
+			return string(end[:i+1])
+		}
+	}
+	return ""
+}
+
+//it verifies if the key is inside the iterated range. The keys outside stop the iteration
+func InRange(key, prefix, start string, reverse bool) bool {
+	if !strings.HasPrefix(key, prefix) {
+		return false
+	}
+	if start != "" {
+		if reverse {
+			return key <= prefix+start
+		}
+		return key >= prefix+start
+	}
+	return true
+}
+
+//the keys are collected first because some stores don't allow deletions while iterating
+func DeleteRange(tx StoreDBTransactionInterface, prefix, start, end string) error {
+
+	if !tx.IsWritable() {
+		return errors.New("Transaction is not writeable")
+	}
+
+	keys := []string{}
+	if err := tx.Iterate(prefix, start, false, func(key string, value []byte) (bool, error) {
+		if end != "" && key >= prefix+end {
+			return false, nil
+		}
+		keys = append(keys, key)
+		return true, nil
+	}); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		tx.Delete(key)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package store_db_interface_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"pandora-pay/store/store_db/store_db_bolt"
+	"pandora-pay/store/store_db/store_db_bunt"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_leveldb"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+func iterateKeys(tx store_db_interface.StoreDBTransactionInterface, prefix, start string, reverse bool) (keys []string) {
+	tx.Iterate(prefix, start, reverse, func(key string, value []byte) (bool, error) {
+		keys = append(keys, key)
+		return true, nil
+	})
+	return
+}
+
+func TestStoreDBIterate(t *testing.T) {
+
+	wd, _ := os.Getwd()
+	assert.Nil(t, os.Chdir(t.TempDir()))
+	defer os.Chdir(wd)
+
+	bolt, err := store_db_bolt.CreateStoreDBBolt("/test")
+	assert.Nil(t, err)
+	bunt, err := store_db_bunt.CreateStoreDBBunt("/test", true)
+	assert.Nil(t, err)
+	leveldb, err := store_db_leveldb.CreateStoreDBLevelDB("/test")
+	assert.Nil(t, err)
+	memory, err := store_db_memory.CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+
+	for _, db := range []store_db_interface.StoreDBInterface{bolt, bunt, leveldb, memory} {
+
+		assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+			for _, key := range []string{"a", "b:1", "b:2", "b:3", "b:4", "c"} {
+				writer.Put(key, []byte(key))
+			}
+			return
+		}))
+
+		assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+
+			//uncommitted changes are visible
+			writer.Put("b:0", []byte{1})
+			writer.Delete("b:3")
+
+			assert.Equal(t, []string{"b:0", "b:1", "b:2", "b:4"}, iterateKeys(writer, "b:", "", false))
+			assert.Equal(t, []string{"b:2", "b:4"}, iterateKeys(writer, "b:", "2", false))
+			assert.Equal(t, []string{"b:4", "b:2", "b:1", "b:0"}, iterateKeys(writer, "b:", "", true))
+			assert.Equal(t, []string{"b:2", "b:1", "b:0"}, iterateKeys(writer, "b:", "3", true))
+			assert.Equal(t, []string{"c", "b:4", "b:2", "b:1", "b:0", "a"}, iterateKeys(writer, "", "", true))
+
+			assert.Nil(t, writer.DeleteRange("b:", "1", "4"))
+			assert.Equal(t, []string{"b:0", "b:4"}, iterateKeys(writer, "b:", "", false))
+			return
+		}))
+
+		assert.Nil(t, db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+			assert.Equal(t, []string{"a", "b:0", "b:4", "c"}, iterateKeys(reader, "", "", false))
+			return
+		}))
+
+		assert.Nil(t, db.Close())
+	}
+
+}
@@ -6,4 +6,8 @@ type StoreDBTransactionInterface interface {
 	Exists(key string) bool
 	Delete(key string)
 	IsWritable() bool
+	//the keys with the prefix are visited in lexicographic order starting with prefix+start (inclusive). Returning false stops the iteration
+	Iterate(prefix, start string, reverse bool, callback func(key string, value []byte) (bool, error)) error
+	//it deletes the keys with the prefix in the range [prefix+start, prefix+end). Empty end deletes until the end of the prefix
+	DeleteRange(prefix, start, end string) error
 }
@@ -6,6 +6,7 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
 	"syscall/js"
 )
 
@@ -151,3 +152,83 @@ func (tx *StoreDBJSTransaction) writeTx() error {
 
 	return nil
 }
+
+func (tx *StoreDBJSTransaction) getStoredKeys() ([]string, error) {
+
+	respCh := make(chan []string)
+	defer close(respCh)
+
+	errCh := make(chan error)
+	defer close(errCh)
+
+	promise := tx.jsStore.Call("keys")
+
+	promise.Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		keys := make([]string, args[0].Length())
+		for i := range keys {
+			keys[i] = args[0].Index(i).String()
+		}
+		respCh <- keys
+		return nil
+	}), js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		errCh <- fmt.Errorf("error reading keys js db %s", args[0].Get("message").String())
+		return nil
+	}))
+
+	select {
+	case keys := <-respCh:
+		return keys, nil
+	case err := <-errCh:
+		return nil, err
+	}
+}
+
+func (tx *StoreDBJSTransaction) Iterate(prefix, start string, reverse bool, callback func(key string, value []byte) (bool, error)) error {
+
+	stored, err := tx.getStoredKeys()
+	if err != nil {
+		return err
+	}
+
+	unique := make(map[string]bool)
+	for _, key := range stored {
+		if store_db_interface.InRange(key, prefix, start, reverse) {
+			unique[key] = true
+		}
+	}
+	tx.local.Range(func(key string, data *StoreDBJSTransactionData) bool {
+		if data.operation == "put" && store_db_interface.InRange(key, prefix, start, reverse) {
+			unique[key] = true
+		}
+		return true
+	})
+
+	keys := make([]string, 0, len(unique))
+	for key := range unique {
+		keys = append(keys, key)
+	}
+
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
+
+	for _, key := range keys {
+		//deleted keys are returned as nil
+		value := tx.Get(key)
+		if value == nil {
+			continue
+		}
+		next, err := callback(key, helpers.CloneBytes(value))
+		if err != nil || !next {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tx *StoreDBJSTransaction) DeleteRange(prefix, start, end string) error {
+	return store_db_interface.DeleteRange(tx, prefix, start, end)
+}
@@ -0,0 +1,87 @@
+package store_db_leveldb
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"os"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sync"
+)
+
+type StoreDBLevelDB struct {
+	store_db_interface.StoreDBInterface
+	DB         *leveldb.DB
+	Name       []byte
+	writeMutex *sync.Mutex //the writes are batched, so only one update is allowed at a time
+}
+
+func (store *StoreDBLevelDB) Close() error {
+	return store.DB.Close()
+}
+
+func (store *StoreDBLevelDB) View(callback func(dbTx store_db_interface.StoreDBTransactionInterface) error) error {
+
+	snapshot, err := store.DB.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	tx := &StoreDBLevelDBTransaction{
+		snapshot: snapshot,
+		local:    make(map[string]*StoreDBLevelDBTransactionData),
+	}
+	return callback(tx)
+}
+
+func (store *StoreDBLevelDB) Update(callback func(dbTx store_db_interface.StoreDBTransactionInterface) error) error {
+	store.writeMutex.Lock()
+	defer store.writeMutex.Unlock()
+
+	snapshot, err := store.DB.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	tx := &StoreDBLevelDBTransaction{
+		snapshot: snapshot,
+		batch:    new(leveldb.Batch),
+		local:    make(map[string]*StoreDBLevelDBTransactionData),
+		write:    true,
+	}
+
+	if err = callback(tx); err != nil {
+		return err
+	}
+
+	return store.DB.Write(tx.batch, nil)
+}
+
+//compaction is done in background by leveldb. The chain compacts it after a large pruning
+func (store *StoreDBLevelDB) Compact() error {
+	return store.DB.CompactRange(util.Range{})
+}
+
+func CreateStoreDBLevelDB(name string) (*StoreDBLevelDB, error) {
+
+	var err error
+
+	store := &StoreDBLevelDB{
+		Name:       []byte(name),
+		writeMutex: &sync.Mutex{},
+	}
+
+	prefix := "./store"
+	if _, err = os.Stat(prefix); os.IsNotExist(err) {
+		if err = os.Mkdir(prefix, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	if store.DB, err = leveldb.OpenFile(prefix+name+"_store"+".leveldb", nil); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
@@ -0,0 +1,189 @@
+package store_db_leveldb_test
+
+import (
+	"os"
+	"pandora-pay/address_balance_decryptor"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain"
+	"pandora-pay/blockchain/blocks/block_complete"
+	"pandora-pay/blockchain/forging"
+	"pandora-pay/blockchain/genesis"
+	"pandora-pay/config"
+	"pandora-pay/config/arguments"
+	"pandora-pay/config/config_stake"
+	"pandora-pay/cryptography"
+	"pandora-pay/gui"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/helpers/msgpack"
+	"pandora-pay/mempool"
+	"pandora-pay/network/network_config"
+	"pandora-pay/network/websocks/connection/advanced_connection_types"
+	"pandora-pay/store"
+	"pandora-pay/store/store_db/store_db_bolt"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_leveldb"
+	"pandora-pay/txs_builder"
+	"pandora-pay/txs_validator"
+	"pandora-pay/wallet"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const replayBlocksCount = 20
+
+var replayMempool *mempool.Mempool
+var replayBlocks [][]byte
+
+//a devnet node forges the blocks once. The staked accounts of the genesis are the ring members of the staking txs
+func forgeReplayBlocks(b *testing.B) {
+
+	if replayBlocks != nil {
+		return
+	}
+
+	wd, _ := os.Getwd()
+	if err := os.Chdir(b.TempDir()); err != nil {
+		b.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	fatal := func(err error) {
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	fatal(arguments.InitArguments([]string{"--network=devnet", "--new-devnet", "--forging", "--skip-init-sync", "--gui-type=non-interactive", "--store-chain-type=memory", "--store-wallet-type=memory", "--balance-decryptor-disable-init"}))
+	fatal(config.InitConfig())
+	fatal(network_config.InitConfig())
+	fatal(gui.InitGUI())
+	fatal(store.InitDB())
+	fatal(txs_validator.NewTxsValidator())
+
+	decryptor, err := address_balance_decryptor.NewAddressBalanceDecryptor(true)
+	fatal(err)
+	if replayMempool, err = mempool.CreateMempool(); err != nil {
+		b.Fatal(err)
+	}
+	forger, err := forging.CreateForging(replayMempool, decryptor)
+	fatal(err)
+	chain, err := blockchain.CreateBlockchain(replayMempool)
+	fatal(err)
+	w, err := wallet.CreateWallet(forger, replayMempool, decryptor)
+	fatal(err)
+
+	address, _, err := w.GetFirstAddressForDevnetGenesisAirdrop()
+	fatal(err)
+
+	genesisData := &genesis.GenesisDataType{
+		Hash:       cryptography.RandomHash(),
+		KernelHash: helpers.DecodeHex("0000000000FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"),
+		Timestamp:  uint64(time.Now().Unix()) - 10*config.BLOCK_TIME*replayBlocksCount,
+		Target:     helpers.DecodeHex("0000000000FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"),
+		AirDrops:   []*genesis.GenesisDataAirDropType{{address, 100 * config_stake.GetRequiredStake(0)}},
+	}
+	for i := 0; i < 200; i++ {
+		addr, err := addresses.GenerateNewPrivateKey().GenerateAddress(true, helpers.RandomBytes(cryptography.PublicKeySize), true, nil, 0, nil)
+		fatal(err)
+		genesisData.AirDrops = append(genesisData.AirDrops, &genesis.GenesisDataAirDropType{addr.EncodeAddr(), 0})
+	}
+	data, err := msgpack.Marshal(genesisData)
+	fatal(err)
+	arguments.Arguments["--set-genesis"] = string(data)
+
+	fatal(genesis.GenesisInit(w.GetFirstAddressForDevnetGenesisAirdrop))
+	fatal(chain.InitializeChain())
+
+	w.InitializeWallet(chain.UpdateNewChainUpdate)
+	fatal(w.StartWallet())
+	fatal(txs_builder.TxsBuilderInit(w, replayMempool))
+
+	updatesCn := chain.UpdateNewChainUpdate.AddListener()
+	defer chain.UpdateNewChainUpdate.RemoveChannel(updatesCn)
+
+	forger.InitializeForging(txs_builder.TxsBuilder.CreateForgingTransactions, chain.NextBlockCreatedCn, chain.UpdateNewChainUpdate, chain.ForgingSolutionCn)
+	forger.StartForging()
+	chain.InitForging()
+
+	blocks := [][]byte{}
+	for len(blocks) < replayBlocksCount {
+		update := <-updatesCn
+		for _, blkComplete := range update.InsertedBlocks {
+			blocks = append(blocks, blkComplete.SerializeManualToBytes())
+		}
+	}
+	forger.StopForging()
+
+	replayBlocks = blocks
+}
+
+//the forged blocks are added again by AddBlocks to a new chain stored in the db
+func replayAddBlocks(b *testing.B, createDB func(name string) (store_db_interface.StoreDBInterface, error)) {
+
+	forgeReplayBlocks(b)
+
+	wd, _ := os.Getwd()
+	if err := os.Chdir(b.TempDir()); err != nil {
+		b.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+
+		b.StopTimer()
+
+		db, err := createDB("/replay" + strconv.Itoa(i))
+		if err != nil {
+			b.Fatal(err)
+		}
+		store.StoreBlockchain.DB = db
+
+		chain, err := blockchain.CreateBlockchain(replayMempool)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err = chain.InitializeChain(); err != nil {
+			b.Fatal(err)
+		}
+
+		blocks := make([]*block_complete.BlockComplete, len(replayBlocks))
+		for j := range replayBlocks {
+			blocks[j] = block_complete.CreateEmptyBlockComplete()
+			if err = blocks[j].Deserialize(advanced_buffers.NewBufferReader(replayBlocks[j])); err != nil {
+				b.Fatal(err)
+			}
+			if err = blocks[j].BloomAll(); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		b.StartTimer()
+
+		if _, err = chain.AddBlocks(blocks, false, advanced_connection_types.UUID_ALL); err != nil {
+			b.Fatal(err)
+		}
+
+		b.StopTimer()
+		if chain.GetChainData().Height != replayBlocksCount {
+			b.Fatal("not all the blocks were added")
+		}
+		db.Close()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkAddBlocksReplayLevelDB(b *testing.B) {
+	replayAddBlocks(b, func(name string) (store_db_interface.StoreDBInterface, error) {
+		return store_db_leveldb.CreateStoreDBLevelDB(name)
+	})
+}
+
+func BenchmarkAddBlocksReplayBolt(b *testing.B) {
+	replayAddBlocks(b, func(name string) (store_db_interface.StoreDBInterface, error) {
+		return store_db_bolt.CreateStoreDBBolt(name)
+	})
+}
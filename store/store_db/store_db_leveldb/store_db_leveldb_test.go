@@ -0,0 +1,53 @@
+package store_db_leveldb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"pandora-pay/store/store_db/store_db_interface"
+	"testing"
+)
+
+func TestStoreDBLevelDB(t *testing.T) {
+
+	wd, _ := os.Getwd()
+	assert.Nil(t, os.Chdir(t.TempDir()))
+	defer os.Chdir(wd)
+
+	store, err := CreateStoreDBLevelDB("/test")
+	assert.Nil(t, err)
+	defer store.Close()
+
+	assert.Nil(t, store.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		writer.Put("a", []byte{1})
+		writer.Put("b", []byte{2})
+		assert.Equal(t, []byte{1}, writer.Get("a"))
+		writer.Delete("b")
+		assert.False(t, writer.Exists("b"))
+		return
+	}))
+
+	//failed updates are discarded
+	assert.NotNil(t, store.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		writer.Put("c", []byte{3})
+		return os.ErrInvalid
+	}))
+
+	assert.Nil(t, store.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		assert.Equal(t, []byte{1}, reader.Get("a"))
+		assert.Nil(t, reader.Get("b"))
+		assert.Nil(t, reader.Get("c"))
+
+		count := 0
+		assert.Nil(t, reader.Iterate("", "", false, func(key string, value []byte) (bool, error) {
+			count += 1
+			return true, nil
+		}))
+		assert.Equal(t, 1, count)
+		return
+	}))
+
+	var db store_db_interface.StoreDBInterface = store
+	compactor, ok := db.(store_db_interface.StoreDBCompactInterface)
+	assert.True(t, ok)
+	assert.Nil(t, compactor.Compact())
+}
@@ -0,0 +1,159 @@
+package store_db_leveldb
+
+import (
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"pandora-pay/helpers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
+)
+
+type StoreDBLevelDBTransactionData struct {
+	value     []byte
+	operation string
+}
+
+type StoreDBLevelDBTransaction struct {
+	store_db_interface.StoreDBTransactionInterface
+	snapshot *leveldb.Snapshot
+	batch    *leveldb.Batch
+	local    map[string]*StoreDBLevelDBTransactionData
+	write    bool
+}
+
+func (tx *StoreDBLevelDBTransaction) IsWritable() bool {
+	return tx.write
+}
+
+func (tx *StoreDBLevelDBTransaction) Put(key string, value []byte) {
+	if !tx.write {
+		panic("Transaction is not writeable")
+	}
+	value = helpers.CloneBytes(value)
+	tx.local[key] = &StoreDBLevelDBTransactionData{value, "put"}
+	tx.batch.Put([]byte(key), value)
+}
+
+func (tx *StoreDBLevelDBTransaction) Get(key string) []byte {
+
+	if data := tx.local[key]; data != nil {
+		if data.operation == "del" {
+			return nil
+		}
+		return helpers.CloneBytes(data.value)
+	}
+
+	//leveldb returns a copy
+	value, err := tx.snapshot.Get([]byte(key), nil)
+	if err != nil {
+		if err != leveldb.ErrNotFound {
+			panic(err)
+		}
+		return nil
+	}
+	return value
+}
+
+func (tx *StoreDBLevelDBTransaction) Exists(key string) bool {
+	if data := tx.local[key]; data != nil {
+		return data.operation == "put"
+	}
+	exists, err := tx.snapshot.Has([]byte(key), nil)
+	if err != nil {
+		panic(err)
+	}
+	return exists
+}
+
+func (tx *StoreDBLevelDBTransaction) Delete(key string) {
+	if !tx.write {
+		panic("Transaction is not writeable")
+	}
+	tx.local[key] = &StoreDBLevelDBTransactionData{nil, "del"}
+	tx.batch.Delete([]byte(key))
+}
+
+func (tx *StoreDBLevelDBTransaction) Iterate(prefix, start string, reverse bool, callback func(key string, value []byte) (bool, error)) (err error) {
+
+	//the local changes are merged with the snapshot
+	local := make([]string, 0)
+	for key := range tx.local {
+		if store_db_interface.InRange(key, prefix, start, reverse) {
+			local = append(local, key)
+		}
+	}
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(local)))
+	} else {
+		sort.Strings(local)
+	}
+
+	var limit []byte
+	if end := store_db_interface.GetPrefixEnd(prefix); end != "" {
+		limit = []byte(end)
+	}
+	r := &util.Range{Start: []byte(prefix), Limit: limit}
+	if start != "" {
+		if reverse {
+			r.Limit = []byte(prefix + start + "\x00")
+		} else {
+			r.Start = []byte(prefix + start)
+		}
+	}
+
+	it := tx.snapshot.NewIterator(r, nil)
+	defer it.Release()
+
+	var valid bool
+	if reverse {
+		valid = it.Last()
+	} else {
+		valid = it.First()
+	}
+
+	c := 0
+	for valid || c < len(local) {
+
+		var key string
+		var value []byte
+
+		if valid {
+			key = string(it.Key())
+		}
+
+		if c < len(local) && (!valid || (!reverse && local[c] <= key) || (reverse && local[c] >= key)) {
+			if valid && local[c] == key {
+				if reverse {
+					valid = it.Prev()
+				} else {
+					valid = it.Next()
+				}
+			}
+			key = local[c]
+			c += 1
+			if data := tx.local[key]; data.operation == "del" {
+				continue
+			} else {
+				value = data.value
+			}
+		} else {
+			value = it.Value()
+			if reverse {
+				valid = it.Prev()
+			} else {
+				valid = it.Next()
+			}
+		}
+
+		var next bool
+		if next, err = callback(key, helpers.CloneBytes(value)); err != nil || !next {
+			return
+		}
+	}
+
+	return it.Error()
+}
+
+func (tx *StoreDBLevelDBTransaction) DeleteRange(prefix, start, end string) error {
+	return store_db_interface.DeleteRange(tx, prefix, start, end)
+}
@@ -0,0 +1,32 @@
+package store_db_memory
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"pandora-pay/store/store_db/store_db_interface"
+	"testing"
+)
+
+func TestStoreDBMemory_Update(t *testing.T) {
+
+	store, err := CreateStoreDBMemory("/test")
+	assert.Nil(t, err)
+
+	assert.Nil(t, store.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		writer.Put("a", []byte{1})
+		return
+	}))
+
+	//the error of the callback is returned and the writes are discarded
+	assert.Equal(t, os.ErrInvalid, store.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		writer.Put("b", []byte{2})
+		writer.Delete("a")
+		return os.ErrInvalid
+	}))
+
+	assert.Nil(t, store.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		assert.Equal(t, []byte{1}, reader.Get("a"))
+		assert.False(t, reader.Exists("b"))
+		return
+	}))
+}
@@ -5,6 +5,7 @@ import (
 	"pandora-pay/helpers"
 	"pandora-pay/helpers/generics"
 	"pandora-pay/store/store_db/store_db_interface"
+	"sort"
 )
 
 type StoreDBMemoryTransactionData struct {
@@ -60,6 +61,48 @@ func (tx *StoreDBMemoryTransaction) Delete(key string) {
 	tx.local.Store(key, &StoreDBMemoryTransactionData{nil, "del"})
 }
 
+func (tx *StoreDBMemoryTransaction) Iterate(prefix, start string, reverse bool, callback func(key string, value []byte) (bool, error)) (err error) {
+
+	//the map is not sorted
+	keys := make([]string, 0)
+	for key := range tx.store {
+		if _, ok := tx.local.Load(key); !ok && store_db_interface.InRange(key, prefix, start, reverse) {
+			keys = append(keys, key)
+		}
+	}
+	tx.local.Range(func(key string, data *StoreDBMemoryTransactionData) bool {
+		if data.operation != "del" && store_db_interface.InRange(key, prefix, start, reverse) {
+			if _, ok := tx.store[key]; ok || data.operation == "put" {
+				keys = append(keys, key)
+			}
+		}
+		return true
+	})
+
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
+
+	for _, key := range keys {
+		value := tx.store[key]
+		if data, ok := tx.local.Load(key); ok && data.operation == "put" {
+			value = data.value
+		}
+		var next bool
+		if next, err = callback(key, helpers.CloneBytes(value)); err != nil || !next {
+			return
+		}
+	}
+
+	return
+}
+
+func (tx *StoreDBMemoryTransaction) DeleteRange(prefix, start, end string) error {
+	return store_db_interface.DeleteRange(tx, prefix, start, end)
+}
+
 func (tx *StoreDBMemoryTransaction) writeTx() error {
 
 	if !tx.write {
@@ -9,6 +9,7 @@ import (
 	"pandora-pay/store/store_db/store_db_bolt"
 	"pandora-pay/store/store_db/store_db_bunt"
 	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_leveldb"
 	"pandora-pay/store/store_db/store_db_memory"
 )
 
@@ -26,6 +27,8 @@ func createStoreNow(name, storeType string) (*Store, error) {
 		db, err = store_db_bunt.CreateStoreDBBunt(name, true)
 	case "memory":
 		db, err = store_db_memory.CreateStoreDBMemory(name)
+	case "leveldb":
+		db, err = store_db_leveldb.CreateStoreDBLevelDB(name)
 	default:
 		err = errors.New("Invalid --store-type argument")
 	}
@@ -46,7 +49,7 @@ func create_db() (err error) {
 
 	var prefix = ""
 
-	allowedStores := map[string]bool{"bolt": true, "bunt": true, "bunt-memory": true, "memory": true}
+	allowedStores := map[string]bool{"bolt": true, "bunt": true, "bunt-memory": true, "memory": true, "leveldb": true}
 
 	if StoreBlockchain, err = createStoreNow(prefix+"/blockchain", getStoreType(arguments.Arguments["--store-chain-type"].(string), allowedStores)); err != nil {
 		return
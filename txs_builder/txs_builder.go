@@ -8,6 +8,9 @@ import (
 	"pandora-pay/blockchain/data_storage/plain_accounts"
 	"pandora-pay/blockchain/data_storage/plain_accounts/plain_account"
 	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_type"
+	"pandora-pay/config/config_fees"
 	"pandora-pay/mempool"
 	"pandora-pay/network/websocks/connection/advanced_connection_types"
 	"pandora-pay/store"
@@ -33,6 +36,42 @@ func (builder *TxsBuilderType) getNonce(nonce uint64, publicKey []byte, accNonce
 	return builder.mempool.GetNonce(publicKey, accNonce)
 }
 
+//the automatic fees are estimated from the mempool and the last blocks. The estimate is the fee per byte of the whole tx, so it is loaded once for all the payloads
+func (builder *TxsBuilderType) setAutoFees(fees []*wizard.WizardTransactionFee, version transaction_type.TransactionVersion) ([]*wizard.WizardTransactionFee, error) {
+
+	var estimate *mempool.FeesEstimate
+	var err error
+
+	out := make([]*wizard.WizardTransactionFee, len(fees))
+	for i, fee := range fees {
+
+		if !fee.PerByteAuto || fee.PerByte != 0 || fee.Fixed != 0 {
+			out[i] = fee
+			continue
+		}
+
+		if estimate == nil {
+			if estimate, err = builder.mempool.EstimateFees(); err != nil {
+				return nil, err
+			}
+		}
+
+		fee = fee.Clone()
+		switch version {
+		case transaction_type.TX_SIMPLE:
+			fee.PerByte = estimate.Simple.Normal
+		case transaction_type.TX_ZETHER:
+			fee.PerByte = estimate.Zether.Normal
+		}
+		if fee.PerByteExtraSpace == 0 {
+			fee.PerByteExtraSpace = config_fees.FEE_PER_BYTE_EXTRA_SPACE
+		}
+		out[i] = fee
+	}
+
+	return out, nil
+}
+
 func (builder *TxsBuilderType) convertFloatAmounts(amounts []float64, ast *asset.Asset) ([]uint64, error) {
 
 	var err error
@@ -78,6 +117,13 @@ func (builder *TxsBuilderType) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, p
 
 	var sendersWalletAddresses []*wallet_address.WalletAddress
 	var err error
+
+	fees, err := builder.setAutoFees([]*wizard.WizardTransactionFee{txData.Fee}, transaction_type.TX_SIMPLE)
+	if err != nil {
+		return nil, err
+	}
+	txData.Fee = fees[0]
+
 	if txData.Sender != "" {
 		if sendersWalletAddresses, err = builder.getWalletAddresses([]string{txData.Sender}); err != nil {
 			return nil, err
@@ -94,6 +140,7 @@ func (builder *TxsBuilderType) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, p
 		txData.Data,
 		txData.Fee,
 		txData.Nonce,
+		txData.ExpiryHeight,
 		nil,
 	}
 
@@ -138,6 +185,58 @@ func (builder *TxsBuilderType) CreateSimpleTx(txData *TxBuilderCreateSimpleTx, p
 	return tx, nil
 }
 
+//the pending tx is replaced in mempool by a copy with a higher fee per byte
+func (builder *TxsBuilderType) BumpSimpleTxFee(txHash []byte, fee *wizard.WizardTransactionFee, propagateTx, awaitAnswer, awaitBroadcast bool, ctx context.Context, statusCallback func(status string)) (*transaction.Transaction, error) {
+
+	mempoolTx := builder.mempool.Txs.Get(string(txHash))
+	if mempoolTx == nil {
+		return nil, errors.New("Transaction was not found in mempool")
+	}
+	if mempoolTx.Tx.Version != transaction_type.TX_SIMPLE {
+		return nil, errors.New("Only simple transactions can be replaced")
+	}
+
+	txBase := mempoolTx.Tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+	if !txBase.HasVin() {
+		return nil, errors.New("Transaction has no input")
+	}
+
+	addr := builder.wallet.GetWalletAddressByPublicKey(txBase.Vin.PublicKey, true)
+	if addr == nil || addr.PrivateKey == nil {
+		return nil, errors.New("Transaction sender is not in the wallet")
+	}
+
+	minimumFeePerByte := config_fees.ComputeReplaceFeePerByte(mempoolTx.FeePerByte)
+
+	if fee == nil || (fee.PerByteAuto && fee.PerByte == 0 && fee.Fixed == 0) {
+		estimate, err := builder.mempool.EstimateFees()
+		if err != nil {
+			return nil, err
+		}
+		fee = &wizard.WizardTransactionFee{0, estimate.Simple.High, config_fees.FEE_PER_BYTE_EXTRA_SPACE, false}
+		if fee.PerByte < minimumFeePerByte {
+			fee.PerByte = minimumFeePerByte
+		}
+	}
+
+	builder.lock.Lock()
+	defer builder.lock.Unlock()
+
+	tx, err := wizard.BumpSimpleTxFee(mempoolTx.Tx, addr.PrivateKey.Key, fee, statusCallback)
+	if err != nil {
+		return nil, err
+	}
+	statusCallback("Transaction Created")
+
+	if propagateTx {
+		if err = builder.mempool.AddTxToMempool(tx, mempoolTx.ChainHeight, true, awaitAnswer, awaitBroadcast, advanced_connection_types.UUID_ALL, ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
 func TxsBuilderInit(wallet *wallet.Wallet, mempool *mempool.Mempool) error {
 
 	TxsBuilder = &TxsBuilderType{
@@ -600,6 +600,28 @@ func (builder *TxsBuilderType) initCLI() {
 		return
 	}
 
+	cliBumpFeePendingTx := func(cmd string, ctx context.Context) (err error) {
+
+		builder.showWarningIfNotSyncCLI()
+
+		txHash := gui.GUI.OutputReadBytes("Pending TxId", func(val []byte) bool {
+			return len(val) == cryptography.HashSize
+		})
+
+		fee := builder.readFee(config_coins.NATIVE_ASSET_FULL)
+		propagate := gui.GUI.OutputReadBool("Propagate? y/n. Leave empty for yes", true, true)
+
+		tx, err := builder.BumpSimpleTxFee(txHash, fee, propagate, true, true, ctx, func(status string) {
+			gui.GUI.OutputWrite(status)
+		})
+		if err != nil {
+			return
+		}
+
+		gui.GUI.OutputWrite(fmt.Sprintf("Tx created: %s %s", base64.StdEncoding.EncodeToString(tx.Bloom.Hash), cmd))
+		return
+	}
+
 	gui.GUI.CommandDefineCallback("Private Transfer", cliPrivateTransfer, true)
 	gui.GUI.CommandDefineCallback("Private Asset Create", cliPrivateAssetCreate, true)
 	gui.GUI.CommandDefineCallback("Private Asset Supply Increase", cliPrivateAssetSupplyIncrease, true)
@@ -607,5 +629,6 @@ func (builder *TxsBuilderType) initCLI() {
 	gui.GUI.CommandDefineCallback("Private Conditional Payment", cliPrivateConditionalPayment, true)
 	gui.GUI.CommandDefineCallback("Public Update Asset Fee Liquidity", cliUpdateAssetFeeLiquidity, true)
 	gui.GUI.CommandDefineCallback("Public Resolution Conditional Payment", cliResolutionConditionalPayment, true)
+	gui.GUI.CommandDefineCallback("Bump Fee Pending Tx", cliBumpFeePendingTx, true)
 
 }
@@ -37,5 +37,6 @@ type TxBuilderCreateZetherTxPayload struct {
 }
 
 type TxBuilderCreateZetherTxData struct {
-	Payloads []*TxBuilderCreateZetherTxPayload `json:"payloads" msgpack:"payloads"`
+	Payloads     []*TxBuilderCreateZetherTxPayload `json:"payloads" msgpack:"payloads"`
+	ExpiryHeight uint64                            `json:"expiryHeight" msgpack:"expiryHeight"`
 }
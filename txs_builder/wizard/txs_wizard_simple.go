@@ -1,6 +1,8 @@
 package wizard
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"pandora-pay/addresses"
 	"pandora-pay/blockchain/transactions/transaction"
@@ -10,8 +12,16 @@ import (
 	"pandora-pay/blockchain/transactions/transaction/transaction_type"
 	"pandora-pay/cryptography"
 	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
 )
 
+//key, version, issuer and the attestations count of a new identity
+const identitySpaceExtra = cryptography.PublicKeySize + 3
+
+func identityAttestationSpaceExtra(jurisdiction string) int {
+	return cryptography.PublicKeySize + cryptography.HashSize + binary.MaxVarintLen64 + len(jurisdiction) + 2*binary.MaxVarintLen64 + 1
+}
+
 func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCallback func(string)) (tx2 *transaction.Transaction, err error) {
 
 	dataFinal, err := transfer.Data.getData()
@@ -57,12 +67,61 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 		}
 		txBase.TxScript = transaction_simple.SCRIPT_RESOLUTION_CONDITIONAL_PAYMENT
 		transfer.Fee = &WizardTransactionFee{0, 0, 0, false}
+	case *WizardTxSimpleExtraBallotClose:
+		txBase.Extra = &transaction_simple_extra.TransactionSimpleExtraBallotClose{nil,
+			txExtra.BallotId,
+			txExtra.Results,
+			txExtra.OptionsPrivateKeys,
+		}
+		txBase.TxScript = transaction_simple.SCRIPT_BALLOT_CLOSE
+		spaceExtra = len(txExtra.Results) * (binary.MaxVarintLen64 + cryptography.PrivateKeySize)
+	case *WizardTxSimpleExtraIdentityAttestationIssue:
+		txBase.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationIssue{nil,
+			txExtra.PublicKey,
+			txExtra.ClaimHash,
+			txExtra.Jurisdiction,
+			txExtra.Expiry,
+		}
+		txBase.TxScript = transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ISSUE
+		spaceExtra = identitySpaceExtra + identityAttestationSpaceExtra(txExtra.Jurisdiction)
+	case *WizardTxSimpleExtraIdentityAttestationRevoke:
+		txBase.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRevoke{nil,
+			txExtra.PublicKey,
+			txExtra.ClaimHash,
+		}
+		txBase.TxScript = transaction_simple.SCRIPT_IDENTITY_ATTESTATION_REVOKE
+		spaceExtra = binary.MaxVarintLen64
+	case *WizardTxSimpleExtraIdentityAttestationRotate:
+		txBase.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityAttestationRotate{nil,
+			txExtra.PublicKey,
+			txExtra.ClaimHash,
+			txExtra.NewClaimHash,
+			txExtra.Jurisdiction,
+			txExtra.Expiry,
+		}
+		txBase.TxScript = transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ROTATE
+		spaceExtra = binary.MaxVarintLen64 + identityAttestationSpaceExtra(txExtra.Jurisdiction)
+	case *WizardTxSimpleExtraNotarizeDocument:
+		txBase.Extra = &transaction_simple_extra.TransactionSimpleExtraNotarizeDocument{nil,
+			txExtra.DocumentHash,
+			txExtra.MimeType,
+			txExtra.Issuer,
+			txExtra.IssuerSignature,
+		}
+		txBase.TxScript = transaction_simple.SCRIPT_NOTARIZE_DOCUMENT
+	case *WizardTxSimpleExtraIdentityIssuerUpdate:
+		txBase.Extra = &transaction_simple_extra.TransactionSimpleExtraIdentityIssuerUpdate{nil,
+			txExtra.PublicKey,
+			txExtra.Issuer,
+		}
+		txBase.TxScript = transaction_simple.SCRIPT_IDENTITY_ISSUER_UPDATE
+		spaceExtra = identitySpaceExtra + 1 + cryptography.PublicKeySize
 	}
 
 	var privateKey *addresses.PrivateKey
 
 	switch txBase.TxScript {
-	case transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY:
+	case transaction_simple.SCRIPT_UPDATE_ASSET_FEE_LIQUIDITY, transaction_simple.SCRIPT_BALLOT_CLOSE, transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ISSUE, transaction_simple.SCRIPT_IDENTITY_ATTESTATION_REVOKE, transaction_simple.SCRIPT_IDENTITY_ATTESTATION_ROTATE, transaction_simple.SCRIPT_NOTARIZE_DOCUMENT, transaction_simple.SCRIPT_IDENTITY_ISSUER_UPDATE:
 		if privateKey, err = addresses.NewPrivateKey(transfer.Key); err != nil {
 			return nil, err
 		}
@@ -79,6 +138,7 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 	tx := &transaction.Transaction{
 		Version:                  transaction_type.TX_SIMPLE,
 		SpaceExtra:               uint64(spaceExtra),
+		ExpiryHeight:             transfer.ExpiryHeight,
 		TransactionBaseInterface: txBase,
 	}
 	statusCallback("Transaction Created")
@@ -115,3 +175,50 @@ func CreateSimpleTx(transfer *WizardTxSimpleTransfer, validateTx bool, statusCal
 
 	return tx, nil
 }
+
+//it creates a copy of a pending tx with the same nonce and a higher fee. The signature is recomputed
+func BumpSimpleTxFee(tx *transaction.Transaction, key []byte, fee *WizardTransactionFee, statusCallback func(string)) (*transaction.Transaction, error) {
+
+	if tx.Version != transaction_type.TX_SIMPLE {
+		return nil, errors.New("Transaction is not a simple transaction")
+	}
+
+	newTx := &transaction.Transaction{}
+	if err := newTx.Deserialize(advanced_buffers.NewBufferReader(tx.Bloom.Serialized)); err != nil {
+		return nil, err
+	}
+
+	txBase := newTx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+	if !txBase.HasVin() {
+		return nil, errors.New("Transaction has no input")
+	}
+
+	privateKey, err := addresses.NewPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(privateKey.GeneratePublicKey(), txBase.Vin.PublicKey) {
+		return nil, errors.New("Private Key is not matching the transaction input")
+	}
+
+	txBase.Vin.Signature = nil
+	txBase.Fee = setFee(newTx, cryptography.SignatureSize, fee.Clone(), true)
+	statusCallback("Transaction Fee set")
+
+	if txBase.Vin.Signature, err = privateKey.Sign(newTx.SerializeForSigning()); err != nil {
+		return nil, err
+	}
+	statusCallback("Transaction Signed")
+
+	newTx.Bloom = nil
+	txBase.Bloom = nil
+	if err = bloomAllTx(newTx, statusCallback); err != nil {
+		return nil, err
+	}
+
+	if err = newTx.TransactionBaseInterface.Validate(); err != nil {
+		return nil, err
+	}
+
+	return newTx, nil
+}
@@ -1 +1,206 @@
 package wizard
+
+import (
+	"github.com/stretchr/testify/assert"
+	"pandora-pay/addresses"
+	"pandora-pay/blockchain/data_storage"
+	"pandora-pay/blockchain/data_storage/accounts/account/account_balance_homomorphic"
+	"pandora-pay/blockchain/data_storage/ballots/ballot"
+	"pandora-pay/blockchain/data_storage/identities/identity"
+	"pandora-pay/blockchain/transactions/transaction"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple"
+	"pandora-pay/blockchain/transactions/transaction/transaction_simple/transaction_simple_extra"
+	"pandora-pay/config/config_ballots"
+	"pandora-pay/config/config_coins"
+	"pandora-pay/cryptography"
+	"pandora-pay/helpers"
+	"pandora-pay/helpers/advanced_buffers"
+	"pandora-pay/store/store_db/store_db_interface"
+	"pandora-pay/store/store_db/store_db_memory"
+	"testing"
+)
+
+type testSimpleChain struct {
+	t      *testing.T
+	db     *store_db_memory.StoreDBMemory
+	nonces map[string]uint64
+}
+
+func (chain *testSimpleChain) createTx(key *addresses.PrivateKey, extra WizardTxSimpleExtra) *transaction.Transaction {
+	tx, err := CreateSimpleTx(&WizardTxSimpleTransfer{
+		extra,
+		&WizardTransactionData{},
+		&WizardTransactionFee{0, 0, 0, true},
+		chain.nonces[string(key.Key)],
+		0,
+		key.Key,
+	}, true, func(string) {})
+	assert.Nil(chain.t, err)
+	return tx
+}
+
+func (chain *testSimpleChain) include(key *addresses.PrivateKey, extra WizardTxSimpleExtra, blockHeight uint64) (err error) {
+
+	tx := chain.createTx(key, extra)
+
+	assert.Nil(chain.t, chain.db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		dataStorage := data_storage.NewDataStorage(writer)
+		if err = tx.IncludeTransaction(blockHeight, dataStorage); err != nil {
+			return nil
+		}
+		return dataStorage.CommitChanges()
+	}))
+
+	if err == nil {
+		chain.nonces[string(key.Key)] += 1
+	}
+	return
+}
+
+func (chain *testSimpleChain) getIdentity(publicKey []byte) (id *identity.Identity) {
+	assert.Nil(chain.t, chain.db.View(func(reader store_db_interface.StoreDBTransactionInterface) (err error) {
+		id, err = data_storage.NewDataStorage(reader).Identities.Get(string(publicKey))
+		return
+	}))
+	return
+}
+
+//the plain accounts of the keys are funded
+func newTestSimpleChain(t *testing.T, keys ...*addresses.PrivateKey) *testSimpleChain {
+
+	db, err := store_db_memory.CreateStoreDBMemory("blockchain")
+	assert.Nil(t, err)
+
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		dataStorage := data_storage.NewDataStorage(writer)
+		for _, key := range keys {
+			plainAcc, err := dataStorage.CreatePlainAccount(key.GeneratePublicKey(), false)
+			assert.Nil(t, err)
+			assert.Nil(t, plainAcc.AddUnclaimed(true, config_coins.ConvertToUnitsUint64Forced(1000)))
+			assert.Nil(t, dataStorage.PlainAccs.Update(string(key.GeneratePublicKey()), plainAcc))
+		}
+		return dataStorage.CommitChanges()
+	}))
+
+	return &testSimpleChain{t, db, make(map[string]uint64)}
+}
+
+func TestCreateSimpleTx_BallotClose(t *testing.T) {
+
+	creator, other := addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()
+	chain := newTestSimpleChain(t, creator, other)
+
+	optionsKeys := []*addresses.PrivateKey{addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()}
+	ballotId := helpers.RandomBytes(config_coins.ASSET_LENGTH)
+
+	assert.Nil(t, chain.db.Update(func(writer store_db_interface.StoreDBTransactionInterface) error {
+		dataStorage := data_storage.NewDataStorage(writer)
+
+		b := ballot.NewBallot(nil, 0)
+		b.Title = "ballot"
+		b.Options = []string{"yes", "no"}
+		b.EligibilityAsset = config_coins.NATIVE_ASSET_FULL
+		b.StartHeight = 10
+		b.EndHeight = 20
+		b.Creator = creator.GeneratePublicKey()
+		for _, key := range optionsKeys {
+			tally, err := account_balance_homomorphic.NewBalanceHomomorphicEmptyBalance(key.GeneratePublicKey())
+			assert.Nil(t, err)
+			b.OptionsPublicKeys = append(b.OptionsPublicKeys, key.GeneratePublicKey())
+			b.Tallies = append(b.Tallies, tally)
+		}
+		b.Tallies[0].AddBalanceUint(3)
+
+		assert.Nil(t, dataStorage.Ballots.CreateBallot(ballotId, b))
+		return dataStorage.CommitChanges()
+	}))
+
+	optionsPrivateKeys := [][]byte{optionsKeys[0].Key, optionsKeys[1].Key}
+	closeBallot := &WizardTxSimpleExtraBallotClose{nil, ballotId, []uint64{3, 0}, optionsPrivateKeys}
+
+	assert.NotNil(t, chain.include(creator, closeBallot, 20))
+	assert.NotNil(t, chain.include(other, closeBallot, 21))
+	assert.NotNil(t, chain.include(creator, &WizardTxSimpleExtraBallotClose{nil, ballotId, []uint64{2, 1}, optionsPrivateKeys}, 21))
+	assert.NotNil(t, chain.include(creator, &WizardTxSimpleExtraBallotClose{nil, ballotId, []uint64{3, 0}, [][]byte{optionsKeys[1].Key, optionsKeys[0].Key}}, 21))
+
+	assert.Nil(t, chain.include(creator, closeBallot, 21))
+	assert.NotNil(t, chain.include(creator, closeBallot, 22))
+
+	assert.Nil(t, chain.db.View(func(reader store_db_interface.StoreDBTransactionInterface) error {
+		b, err := data_storage.NewDataStorage(reader).Ballots.Get(string(ballotId))
+		assert.Nil(t, err)
+		assert.True(t, b.Closed)
+		assert.Equal(t, []uint64{3, 0}, b.Results)
+		return err
+	}))
+
+	//the options count is serialized as a byte
+	tx := chain.createTx(creator, closeBallot)
+	assert.Nil(t, (&transaction.Transaction{}).Deserialize(advanced_buffers.NewBufferReader(tx.SerializeManualToBytes())))
+	txBase := tx.TransactionBaseInterface.(*transaction_simple.TransactionSimple)
+	extra := txBase.Extra.(*transaction_simple_extra.TransactionSimpleExtraBallotClose)
+	for len(extra.Results) <= int(config_ballots.BALLOT_OPTIONS_MAX_BYTE) {
+		extra.Results = append(extra.Results, 0)
+		extra.OptionsPrivateKeys = append(extra.OptionsPrivateKeys, optionsKeys[0].Key)
+	}
+	assert.NotNil(t, (&transaction.Transaction{}).Deserialize(advanced_buffers.NewBufferReader(tx.SerializeManualToBytes())))
+}
+
+func TestCreateSimpleTx_Identities(t *testing.T) {
+
+	root, issuer, other := addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey(), addresses.GenerateNewPrivateKey()
+	subject := addresses.GenerateNewPrivateKey().GeneratePublicKey()
+
+	chain := newTestSimpleChain(t, root, issuer, other)
+	db := chain.db
+
+	assert.Nil(t, db.Update(func(writer store_db_interface.StoreDBTransactionInterface) (err error) {
+		dataStorage := data_storage.NewDataStorage(writer)
+		_, err = dataStorage.CreateRegistration(subject, false, nil)
+		assert.Nil(t, err)
+
+		//genesis issuer
+		id := identity.NewIdentity(root.GeneratePublicKey(), 0)
+		id.Issuer = true
+		assert.Nil(t, dataStorage.Identities.Create(string(root.GeneratePublicKey()), id))
+
+		return dataStorage.CommitChanges()
+	}))
+
+	claimHash, newClaimHash := cryptography.RandomHash(), cryptography.RandomHash()
+	issue := &WizardTxSimpleExtraIdentityAttestationIssue{nil, subject, claimHash, "RO", 100}
+
+	//only the accredited issuers can issue
+	assert.NotNil(t, chain.include(issuer, issue, 10))
+	assert.Nil(t, chain.include(root, &WizardTxSimpleExtraIdentityIssuerUpdate{nil, issuer.GeneratePublicKey(), true}, 10))
+	assert.Equal(t, root.GeneratePublicKey(), chain.getIdentity(issuer.GeneratePublicKey()).AccreditedBy)
+	assert.NotNil(t, chain.include(root, &WizardTxSimpleExtraIdentityIssuerUpdate{nil, subject, true}, 10))
+
+	assert.Nil(t, chain.include(issuer, issue, 10))
+	assert.NotNil(t, chain.include(issuer, issue, 11))
+	assert.Equal(t, 1, len(chain.getIdentity(subject).GetActiveAttestations(11)))
+
+	//the expired attestations can't be rotated or revoked
+	rotate := &WizardTxSimpleExtraIdentityAttestationRotate{nil, subject, claimHash, newClaimHash, "RO", 300}
+	assert.NotNil(t, chain.include(issuer, rotate, 100))
+	assert.NotNil(t, chain.include(issuer, &WizardTxSimpleExtraIdentityAttestationRevoke{nil, subject, claimHash}, 100))
+
+	assert.Nil(t, chain.include(issuer, rotate, 20))
+	active := chain.getIdentity(subject).GetActiveAttestations(20)
+	assert.Equal(t, 1, len(active))
+	assert.Equal(t, newClaimHash, active[0].ClaimHash)
+
+	assert.Nil(t, chain.include(issuer, &WizardTxSimpleExtraIdentityAttestationRevoke{nil, subject, newClaimHash}, 30))
+	assert.Equal(t, 0, len(chain.getIdentity(subject).GetActiveAttestations(30)))
+
+	//only the issuer that accredited it can remove the accreditation
+	assert.Nil(t, chain.include(root, &WizardTxSimpleExtraIdentityIssuerUpdate{nil, other.GeneratePublicKey(), true}, 40))
+	assert.NotNil(t, chain.include(other, &WizardTxSimpleExtraIdentityIssuerUpdate{nil, issuer.GeneratePublicKey(), false}, 40))
+	assert.Nil(t, chain.include(root, &WizardTxSimpleExtraIdentityIssuerUpdate{nil, issuer.GeneratePublicKey(), false}, 40))
+	assert.Nil(t, chain.getIdentity(issuer.GeneratePublicKey()))
+	assert.NotNil(t, chain.include(issuer, &WizardTxSimpleExtraIdentityAttestationIssue{nil, subject, cryptography.RandomHash(), "RO", 100}, 50))
+
+	//an issuer can renounce its own accreditation
+	assert.Nil(t, chain.include(other, &WizardTxSimpleExtraIdentityIssuerUpdate{nil, other.GeneratePublicKey(), false}, 50))
+	assert.Nil(t, chain.getIdentity(other.GeneratePublicKey()))
+}
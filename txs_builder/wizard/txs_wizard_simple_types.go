@@ -23,10 +23,55 @@ type WizardTxSimpleExtraResolutionConditionalPayment struct {
 	Signatures          [][]byte `json:"signatures" msgpack:"signatures"`
 }
 
+type WizardTxSimpleExtraBallotClose struct {
+	WizardTxSimpleExtra `json:"-"  msgpack:"-"`
+	BallotId            []byte   `json:"ballotId" msgpack:"ballotId"`
+	Results             []uint64 `json:"results" msgpack:"results"`
+	OptionsPrivateKeys  [][]byte `json:"optionsPrivateKeys" msgpack:"optionsPrivateKeys"`
+}
+
+type WizardTxSimpleExtraIdentityAttestationIssue struct {
+	WizardTxSimpleExtra `json:"-"  msgpack:"-"`
+	PublicKey           []byte `json:"publicKey" msgpack:"publicKey"`
+	ClaimHash           []byte `json:"claimHash" msgpack:"claimHash"`
+	Jurisdiction        string `json:"jurisdiction" msgpack:"jurisdiction"`
+	Expiry              uint64 `json:"expiry" msgpack:"expiry"`
+}
+
+type WizardTxSimpleExtraIdentityAttestationRevoke struct {
+	WizardTxSimpleExtra `json:"-"  msgpack:"-"`
+	PublicKey           []byte `json:"publicKey" msgpack:"publicKey"`
+	ClaimHash           []byte `json:"claimHash" msgpack:"claimHash"`
+}
+
+type WizardTxSimpleExtraIdentityAttestationRotate struct {
+	WizardTxSimpleExtra `json:"-"  msgpack:"-"`
+	PublicKey           []byte `json:"publicKey" msgpack:"publicKey"`
+	ClaimHash           []byte `json:"claimHash" msgpack:"claimHash"`
+	NewClaimHash        []byte `json:"newClaimHash" msgpack:"newClaimHash"`
+	Jurisdiction        string `json:"jurisdiction" msgpack:"jurisdiction"`
+	Expiry              uint64 `json:"expiry" msgpack:"expiry"`
+}
+
+type WizardTxSimpleExtraNotarizeDocument struct {
+	WizardTxSimpleExtra `json:"-"  msgpack:"-"`
+	DocumentHash        []byte `json:"documentHash" msgpack:"documentHash"`
+	MimeType            string `json:"mimeType" msgpack:"mimeType"`
+	Issuer              []byte `json:"issuer,omitempty" msgpack:"issuer,omitempty"`
+	IssuerSignature     []byte `json:"issuerSignature,omitempty" msgpack:"issuerSignature,omitempty"`
+}
+
+type WizardTxSimpleExtraIdentityIssuerUpdate struct {
+	WizardTxSimpleExtra `json:"-"  msgpack:"-"`
+	PublicKey           []byte `json:"publicKey" msgpack:"publicKey"`
+	Issuer              bool   `json:"issuer" msgpack:"issuer"`
+}
+
 type WizardTxSimpleTransfer struct {
-	Extra WizardTxSimpleExtra    `json:"extra" msgpack:"extra"`
-	Data  *WizardTransactionData `json:"data" msgpack:"data"`
-	Fee   *WizardTransactionFee  `json:"fee" msgpack:"fee"`
-	Nonce uint64                 `json:"nonce" msgpack:"nonce"`
-	Key   []byte                 `json:"key" msgpack:"key"`
+	Extra        WizardTxSimpleExtra    `json:"extra" msgpack:"extra"`
+	Data         *WizardTransactionData `json:"data" msgpack:"data"`
+	Fee          *WizardTransactionFee  `json:"fee" msgpack:"fee"`
+	Nonce        uint64                 `json:"nonce" msgpack:"nonce"`
+	ExpiryHeight uint64                 `json:"expiryHeight" msgpack:"expiryHeight"`
+	Key          []byte                 `json:"key" msgpack:"key"`
 }
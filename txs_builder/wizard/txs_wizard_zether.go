@@ -318,6 +318,30 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 					payloadExtra.Threshold,
 					payloadExtra.MultisigPublicKeys,
 				}
+			case *WizardZetherPayloadExtraBallotCreate:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_BALLOT_CREATE
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotCreate{
+					nil,
+					payloadExtra.Title,
+					payloadExtra.Options,
+					payloadExtra.OptionsPublicKeys,
+					payloadExtra.OptionsRegistrationSignatures,
+					payloadExtra.EligibilityAsset,
+					payloadExtra.StartHeight,
+					payloadExtra.EndHeight,
+					payloadExtra.Creator,
+				}
+
+				spaceExtra += config_coins.ASSET_LENGTH + cryptography.PublicKeySize + len(payloadExtra.Options)*(cryptography.PublicKeySize+1+66)
+				for _, option := range payloadExtra.Options {
+					spaceExtra += len(option) + 66
+				}
+			case *WizardZetherPayloadExtraBallotVote:
+				payloads[t].PayloadScript = transaction_zether_payload_script.SCRIPT_BALLOT_VOTE
+				payloads[t].Extra = &transaction_zether_payload_extra.TransactionZetherPayloadExtraBallotVote{
+					nil,
+					payloadExtra.BallotId,
+				}
 			default:
 				return errors.New("Invalid payload")
 			}
@@ -564,7 +588,7 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 
 				} else { //receiver
 					if (bytes.Equal(payload.Asset, config_coins.NATIVE_ASSET_FULL) && hasRollovers[publickeylist[i].String()]) ||
-						payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT {
+						payload.PayloadScript == transaction_zether_payload_script.SCRIPT_CONDITIONAL_PAYMENT || payload.PayloadScript == transaction_zether_payload_script.SCRIPT_BALLOT_VOTE {
 						update = false
 					}
 				}
@@ -657,7 +681,7 @@ func signZetherTx(tx *transaction.Transaction, txBase *transaction_zether.Transa
 	return
 }
 
-func CreateZetherTx(transfers []*WizardZetherTransfer, emap map[string]map[string][]byte, hasRollovers map[string]bool, ringsSenderMembers, ringsRecipientMembers [][]*bn256.G1, chainHeight uint64, chainKernelHash []byte, publicKeyIndexes map[string]*WizardZetherPublicKeyIndex, fees []*WizardTransactionFee, ctx context.Context, statusCallback func(string)) (tx2 *transaction.Transaction, err error) {
+func CreateZetherTx(transfers []*WizardZetherTransfer, emap map[string]map[string][]byte, hasRollovers map[string]bool, ringsSenderMembers, ringsRecipientMembers [][]*bn256.G1, chainHeight uint64, chainKernelHash []byte, publicKeyIndexes map[string]*WizardZetherPublicKeyIndex, fees []*WizardTransactionFee, expiryHeight uint64, ctx context.Context, statusCallback func(string)) (tx2 *transaction.Transaction, err error) {
 
 	for i, transfer := range transfers {
 		if transfer.SenderSpendRequired {
@@ -678,6 +702,7 @@ func CreateZetherTx(transfers []*WizardZetherTransfer, emap map[string]map[strin
 
 	tx := &transaction.Transaction{
 		Version:                  transaction_type.TX_ZETHER,
+		ExpiryHeight:             expiryHeight,
 		TransactionBaseInterface: txBase,
 	}
 
@@ -108,7 +108,7 @@ func TestCreateZetherTx2(t *testing.T) {
 	hasRollovers := make(map[string]bool)
 
 	hash := helpers.RandomBytes(32)
-	tx, err := CreateZetherTx(transfers, emap, hasRollovers, ringsSenders, ringsReceivers, 0, hash, publicKeyIndexes, fees, ctx, func(status string) {})
+	tx, err := CreateZetherTx(transfers, emap, hasRollovers, ringsSenders, ringsReceivers, 0, hash, publicKeyIndexes, fees, 0, ctx, func(status string) {})
 	assert.NoError(t, err)
 	assert.NotNil(t, t, tx)
 
@@ -43,6 +43,23 @@ type WizardZetherPayloadExtraConditionalPayment struct {
 	MultisigPublicKeys       [][]byte `json:"multisigPublicKeys" msgpack:"multisigPublicKeys"`
 }
 
+type WizardZetherPayloadExtraBallotCreate struct {
+	WizardZetherPayloadExtra      `json:"-" msgpack:""`
+	Title                         string   `json:"title" msgpack:"title"`
+	Options                       []string `json:"options" msgpack:"options"`
+	OptionsPublicKeys             [][]byte `json:"optionsPublicKeys" msgpack:"optionsPublicKeys"`
+	OptionsRegistrationSignatures [][]byte `json:"optionsRegistrationSignatures" msgpack:"optionsRegistrationSignatures"`
+	EligibilityAsset              []byte   `json:"eligibilityAsset" msgpack:"eligibilityAsset"`
+	StartHeight                   uint64   `json:"startHeight" msgpack:"startHeight"`
+	EndHeight                     uint64   `json:"endHeight" msgpack:"endHeight"`
+	Creator                       []byte   `json:"creator" msgpack:"creator"`
+}
+
+type WizardZetherPayloadExtraBallotVote struct {
+	WizardZetherPayloadExtra `json:"-" msgpack:""`
+	BallotId                 []byte `json:"ballotId" msgpack:"ballotId"`
+}
+
 type WizardZetherPayloadExtra interface {
 }
 